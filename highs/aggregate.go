@@ -0,0 +1,264 @@
+package highs
+
+import (
+	"math"
+	"sort"
+)
+
+// aggregatePivotTol is the smallest magnitude, relative to the largest
+// coefficient of its row, that AggregateEqualities accepts for the
+// coefficient of the column it eliminates, keeping the substitution
+// numerically stable.
+const aggregatePivotTol = 0.01
+
+// aggregateDropTol is the magnitude below which a coefficient produced by
+// a substitution is treated as cancelled.
+const aggregateDropTol = 1e-12
+
+// Aggregation records the columns removed by AggregateEqualities, so a
+// solution of the reduced model can be mapped back to the original.
+type Aggregation struct {
+	// Kept holds the original index of each column of the reduced model.
+	Kept []int
+
+	// Substitutions lists the eliminated columns in the order they were
+	// eliminated.
+	Substitutions []Substitution
+
+	// NumCols is the number of columns of the original model.
+	NumCols int
+}
+
+// Substitution expresses an eliminated column through an equality row:
+// x[Col] = Constant + Σ Coeffs[i] * x[Cols[i]], with all indices those of
+// the original model. Each of Cols is kept or eliminated by a later
+// substitution.
+type Substitution struct {
+	// Col is the eliminated column.
+	Col int
+
+	// Row is the equality row the substitution was derived from.
+	Row int
+
+	Constant float64
+	Cols     []int
+	Coeffs   []float64
+}
+
+// ExpandColValues returns the column values of the original model given
+// those of the reduced model, evaluating the substitutions in reverse.
+func (a *Aggregation) ExpandColValues(reduced []float64) []float64 {
+	values := make([]float64, a.NumCols)
+	for i, col := range a.Kept {
+		if i < len(reduced) {
+			values[col] = reduced[i]
+		}
+	}
+	for i := len(a.Substitutions) - 1; i >= 0; i-- {
+		sub := a.Substitutions[i]
+		v := sub.Constant
+		for j, col := range sub.Cols {
+			v += sub.Coeffs[j] * values[col]
+		}
+		values[sub.Col] = v
+	}
+	return values
+}
+
+// AggregateEqualities eliminates one column from each equality row with
+// at least two entries, such as z in x + y - z = 0, by substituting its
+// expression in the other columns of the row into the rest of the model,
+// then renumbers the remaining columns. The equality row itself keeps its
+// index but loses the eliminated column: it now bounds the remaining
+// terms so that the eliminated column stays within its bounds, and is
+// free if that column was free. Rows are processed in order, so a later
+// row sees the substitutions made before it.
+//
+// Only continuous columns outside the Hessian are eliminated, choosing in
+// each row the one in the fewest rows, to limit fill-in, among those whose
+// coefficient is not much smaller than the row's largest. Duplicate matrix
+// entries are merged first, keeping the last value. The reduced model has
+// the same optimal objective, and ExpandColValues recovers the original
+// columns from its solution.
+func (m *Model) AggregateEqualities() (*Aggregation, error) {
+	numCol := m.NumVars()
+	colCosts, err := expandSlice(numCol, m.ColCosts, 0)
+	if err != nil {
+		return nil, newErrorMsg("AggregateEqualities", "inconsistent ColCosts length")
+	}
+	colLower, err := expandSlice(numCol, m.ColLower, math.Inf(-1))
+	if err != nil {
+		return nil, newErrorMsg("AggregateEqualities", "inconsistent ColLower length")
+	}
+	colUpper, err := expandSlice(numCol, m.ColUpper, math.Inf(1))
+	if err != nil {
+		return nil, newErrorMsg("AggregateEqualities", "inconsistent ColUpper length")
+	}
+	numRow := m.NumConstraints()
+	rowLower, err := expandSlice(numRow, m.RowLower, math.Inf(-1))
+	if err != nil {
+		return nil, newErrorMsg("AggregateEqualities", "inconsistent RowLower length")
+	}
+	rowUpper, err := expandSlice(numRow, m.RowUpper, math.Inf(1))
+	if err != nil {
+		return nil, newErrorMsg("AggregateEqualities", "inconsistent RowUpper length")
+	}
+
+	pivotable := make([]bool, numCol)
+	for col := range pivotable {
+		pivotable[col] = col >= len(m.VarTypes) || m.VarTypes[col] == Continuous
+	}
+	for _, nz := range m.Hessian {
+		for _, col := range [2]int{nz.Row, nz.Col} {
+			if col >= 0 && col < numCol {
+				pivotable[col] = false
+			}
+		}
+	}
+
+	// Hold the matrix as sparse rows plus the rows of each column, and
+	// copy so the caller's slices are not modified in place
+	rows := make([]map[int]float64, numRow)
+	colRows := make([]map[int]bool, numCol)
+	for i := range rows {
+		rows[i] = make(map[int]float64)
+	}
+	for i := range colRows {
+		colRows[i] = make(map[int]bool)
+	}
+	for _, nz := range sortedEntries(m.ConstMatrix) {
+		rows[nz.Row][nz.Col] = nz.Val
+		colRows[nz.Col][nz.Row] = true
+	}
+	costs := append([]float64(nil), colCosts...)
+	rowLower = append([]float64(nil), rowLower...)
+	rowUpper = append([]float64(nil), rowUpper...)
+	offset := m.Offset
+
+	agg := &Aggregation{NumCols: numCol}
+	eliminated := make([]bool, numCol)
+	for r := range rows {
+		b := rowLower[r]
+		if b != rowUpper[r] || !isFiniteBound(b) || len(rows[r]) < 2 {
+			continue
+		}
+		var scale float64
+		for _, v := range rows[r] {
+			scale = max(scale, math.Abs(v))
+		}
+		pivot := -1
+		for col, v := range rows[r] {
+			if !pivotable[col] || math.Abs(v) < aggregatePivotTol*scale {
+				continue
+			}
+			if pivot < 0 || len(colRows[col]) < len(colRows[pivot]) ||
+				(len(colRows[col]) == len(colRows[pivot]) && col < pivot) {
+				pivot = col
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+
+		a := rows[r][pivot]
+		sub := Substitution{Col: pivot, Row: r, Constant: b / a}
+		for _, col := range sortedKeys(rows[r]) {
+			if col != pivot {
+				sub.Cols = append(sub.Cols, col)
+				sub.Coeffs = append(sub.Coeffs, -rows[r][col]/a)
+			}
+		}
+
+		for _, s := range sortedKeys(colRows[pivot]) {
+			if s == r {
+				continue
+			}
+			c := rows[s][pivot]
+			delete(rows[s], pivot)
+			rowLower[s] -= c * sub.Constant
+			rowUpper[s] -= c * sub.Constant
+			for j, col := range sub.Cols {
+				if v := rows[s][col] + c*sub.Coeffs[j]; math.Abs(v) < aggregateDropTol {
+					delete(rows[s], col)
+					delete(colRows[col], s)
+				} else {
+					rows[s][col] = v
+					colRows[col][s] = true
+				}
+			}
+		}
+		if cost := costs[pivot]; cost != 0 {
+			offset += cost * sub.Constant
+			for j, col := range sub.Cols {
+				costs[col] += cost * sub.Coeffs[j]
+			}
+			costs[pivot] = 0
+		}
+
+		// a * x[pivot] = b - (rest of the row) within the pivot's bounds
+		lower, upper := colLower[pivot], colUpper[pivot]
+		if !isFiniteBound(lower) {
+			lower = math.Inf(-1)
+		}
+		if !isFiniteBound(upper) {
+			upper = math.Inf(1)
+		}
+		if a > 0 {
+			rowLower[r], rowUpper[r] = b-a*upper, b-a*lower
+		} else {
+			rowLower[r], rowUpper[r] = b-a*lower, b-a*upper
+		}
+		delete(rows[r], pivot)
+		colRows[pivot] = nil
+		pivotable[pivot] = false
+		eliminated[pivot] = true
+		agg.Substitutions = append(agg.Substitutions, sub)
+	}
+
+	newIndex := make([]int, numCol)
+	for col := range newIndex {
+		if eliminated[col] {
+			newIndex[col] = -1
+			continue
+		}
+		newIndex[col] = len(agg.Kept)
+		agg.Kept = append(agg.Kept, col)
+	}
+	if len(agg.Substitutions) == 0 {
+		return agg, nil
+	}
+
+	var matrix []Nonzero
+	for r, row := range rows {
+		for _, col := range sortedKeys(row) {
+			matrix = append(matrix, Nonzero{Row: r, Col: newIndex[col], Val: row[col]})
+		}
+	}
+	var hessian []Nonzero
+	for _, nz := range m.Hessian {
+		nz.Row, nz.Col = newIndex[nz.Row], newIndex[nz.Col]
+		hessian = append(hessian, nz)
+	}
+
+	keep := func(n int) bool { return n < numCol && newIndex[n] >= 0 }
+	m.ColCosts = compactFloat64s(costs, keep)
+	m.ColLower = compactFloat64s(colLower, keep)
+	m.ColUpper = compactFloat64s(colUpper, keep)
+	m.keepColumnAttrs(agg.Kept)
+	m.RowLower = rowLower
+	m.RowUpper = rowUpper
+	m.ConstMatrix = matrix
+	m.Hessian = hessian
+	m.Offset = offset
+	return agg, nil
+}
+
+// sortedKeys returns the keys of m in increasing order.
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
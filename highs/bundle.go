@@ -0,0 +1,190 @@
+package highs
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Bundle entry names.
+const (
+	bundleModel    = "model.mps"
+	bundleOptions  = "options.txt"
+	bundleSolution = "solution.bin"
+)
+
+// SaveBundle writes the model, the options that opts set, and solution
+// to a zip file at path, packaging a reproducible case for sharing or
+// regression suites. The bundle holds model.mps, options.txt with the
+// options that differ from the HiGHS defaults, and, unless solution is
+// nil, solution.bin with the solution in the MarshalBinary encoding,
+// since HiGHS cannot read its own solution files back. Options that
+// direct the log, such as WithSolveTrace and WithLogWriter, are not
+// applied or recorded. If writing fails, the file at path is removed.
+func (m *Model) SaveBundle(path string, solution *Solution, opts ...SolveOption) error {
+	dir, err := os.MkdirTemp("", "gohighs-bundle-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	solver, err := NewSolver()
+	if err != nil {
+		return err
+	}
+	defer solver.Close()
+	cfg := defaultSolveConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if err := cfg.applyOptions(solver); err != nil {
+		return err
+	}
+	if err := m.load(solver); err != nil {
+		return err
+	}
+	modelPath := filepath.Join(dir, bundleModel)
+	if err := solver.WriteModel(modelPath); err != nil {
+		return err
+	}
+	optionsPath := filepath.Join(dir, bundleOptions)
+	if err := solver.WriteModifiedOptions(optionsPath); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = writeBundle(f, modelPath, optionsPath, solution)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// writeBundle writes the bundle entries as a zip archive to w, reading
+// the model and options from the files HiGHS wrote.
+func writeBundle(w io.Writer, modelPath, optionsPath string, solution *Solution) error {
+	zw := zip.NewWriter(w)
+	for _, entry := range []struct{ name, path string }{
+		{bundleModel, modelPath},
+		{bundleOptions, optionsPath},
+	} {
+		data, err := os.ReadFile(entry.path)
+		if err != nil {
+			return err
+		}
+		if err := writeZipEntry(zw, entry.name, data); err != nil {
+			return err
+		}
+	}
+	if solution != nil {
+		data, err := solution.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if err := writeZipEntry(zw, bundleSolution, data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeZipEntry adds a compressed file with the given contents to zw.
+func writeZipEntry(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// LoadBundle reads a bundle written by SaveBundle, returning its model
+// and solution. The solution is nil if the bundle has none. The model is
+// read back through HiGHS, so ColNames and RowNames hold the names in the
+// MPS file, which HiGHS generates for unnamed columns and rows. The
+// options are not applied; they are kept in the bundle for reference.
+func LoadBundle(path string) (*Model, *Solution, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer zr.Close()
+
+	var modelData, solutionData []byte
+	for _, f := range zr.File {
+		var dst *[]byte
+		switch f.Name {
+		case bundleModel:
+			dst = &modelData
+		case bundleSolution:
+			dst = &solutionData
+		default:
+			continue
+		}
+		if *dst, err = readZipEntry(f); err != nil {
+			return nil, nil, err
+		}
+	}
+	if modelData == nil {
+		return nil, nil, newErrorMsg("LoadBundle", "bundle has no "+bundleModel)
+	}
+
+	dir, err := os.MkdirTemp("", "gohighs-bundle-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+	modelPath := filepath.Join(dir, bundleModel)
+	if err := os.WriteFile(modelPath, modelData, 0o600); err != nil {
+		return nil, nil, err
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		return nil, nil, err
+	}
+	if err := solver.ReadModel(modelPath); err != nil {
+		return nil, nil, err
+	}
+	model, err := solver.GetModel()
+	if err != nil {
+		return nil, nil, err
+	}
+	if names, err := solver.ColNames(); err == nil {
+		model.ColNames = names
+	}
+	if names, err := solver.RowNames(); err == nil {
+		model.RowNames = names
+	}
+
+	var solution *Solution
+	if solutionData != nil {
+		solution = &Solution{}
+		if err := solution.UnmarshalBinary(solutionData); err != nil {
+			return nil, nil, err
+		}
+	}
+	return model, solution, nil
+}
+
+// readZipEntry returns the contents of a zip file entry.
+func readZipEntry(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,117 @@
+package highs
+
+import (
+	"container/list"
+	"sync"
+)
+
+// SolutionCache is an in-memory LRU cache of solutions keyed by Model.Hash.
+// It lets applications that repeatedly solve identical models, such as
+// interactive UIs re-rendering, skip the solver entirely.
+//
+// The cache key covers the model only, not the solve options. Use a
+// separate cache for each distinct option set. Cached solutions are shared
+// between callers and must not be modified.
+//
+// A SolutionCache is safe for concurrent use.
+type SolutionCache struct {
+	mu      sync.Mutex
+	maxSize int
+	order   *list.List // front is most recently used
+	items   map[string]*list.Element
+	hits    int
+	misses  int
+
+	// solve is the function used on a cache miss; replaced in tests.
+	solve func(*Model, ...SolveOption) (*Solution, error)
+}
+
+type cacheEntry struct {
+	key      string
+	solution *Solution
+}
+
+// NewSolutionCache creates a cache holding at most maxSize solutions.
+// When full, the least recently used solution is evicted.
+func NewSolutionCache(maxSize int) *SolutionCache {
+	return &SolutionCache{
+		maxSize: maxSize,
+		order:   list.New(),
+		items:   make(map[string]*list.Element),
+		solve:   (*Model).Solve,
+	}
+}
+
+// Get returns the cached solution for the model, if present.
+func (c *SolutionCache) Get(m *Model) (*Solution, bool) {
+	return c.get(m.Hash())
+}
+
+// Put stores the solution for the model, evicting the least recently
+// used entry if the cache is full.
+func (c *SolutionCache) Put(m *Model, solution *Solution) {
+	c.put(m.Hash(), solution)
+}
+
+// Solve returns the cached solution for the model, solving and caching
+// it on a miss. Failed solves are not cached.
+func (c *SolutionCache) Solve(m *Model, opts ...SolveOption) (*Solution, error) {
+	key := m.Hash()
+	if sol, ok := c.get(key); ok {
+		return sol, nil
+	}
+	sol, err := c.solve(m, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, sol)
+	return sol, nil
+}
+
+// Len returns the number of cached solutions.
+func (c *SolutionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns the number of cache hits and misses so far.
+func (c *SolutionCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+func (c *SolutionCache) get(key string) (*Solution, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).solution, true
+}
+
+func (c *SolutionCache) put(key string, solution *Solution) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maxSize <= 0 {
+		return
+	}
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*cacheEntry).solution = solution
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, solution: solution})
+	for c.order.Len() > c.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build cgo && (linux || darwin) && (amd64 || arm64)
+
+#include <stdint.h>
+#include "highs_c_api.h"
+#include "_cgo_export.h"
+
+// gohighs_callback adapts the HiGHS callback signature (which uses const
+// pointers) to the exported Go function.
+static void gohighs_callback(int callback_type, const char* message,
+                             const HighsCallbackDataOut* data_out,
+                             HighsCallbackDataIn* data_in, void* user_data) {
+	gohighsCallback(callback_type, (char*)message,
+	                (HighsCallbackDataOut*)data_out, data_in, user_data);
+}
+
+// gohighs_setCallback registers gohighs_callback with the cgo.Handle of
+// the solver's callback state as user data.
+HighsInt gohighs_setCallback(void* highs, uintptr_t handle) {
+	return Highs_setCallback(highs, gohighs_callback, (void*)handle);
+}
@@ -0,0 +1,324 @@
+//go:build cgo && (linux || darwin) && (amd64 || arm64)
+
+package highs
+
+/*
+#include <stdint.h>
+#include "highs_c_api.h"
+
+HighsInt gohighs_setCallback(void* highs, uintptr_t handle);
+*/
+import "C"
+import (
+	"context"
+	"math"
+	"runtime/cgo"
+	"time"
+	"unsafe"
+)
+
+// numCallbackTypes is the number of HiGHS callback types
+// (kHighsCallbackLogging through kHighsCallbackCallbackMipUserSolution).
+const numCallbackTypes = 10
+
+// callbackHandler handles one HiGHS callback event. in may be used to
+// interrupt the solve. Handlers run on the solving goroutine and must
+// not panic, since the panic would unwind through HiGHS.
+type callbackHandler func(message *C.char, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn)
+
+// callbackState holds a solver's callback handlers. HiGHS receives a
+// cgo.Handle to it rather than to the Solver, so registering callbacks
+// does not keep the Solver reachable and its finalizer still runs.
+type callbackState struct {
+	handle   cgo.Handle
+	handlers [numCallbackTypes]callbackHandler
+}
+
+//export gohighsCallback
+func gohighsCallback(callbackType C.int, message *C.char, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn, userData unsafe.Pointer) {
+	state := cgo.Handle(uintptr(userData)).Value().(*callbackState)
+	if callbackType < 0 || int(callbackType) >= numCallbackTypes {
+		return
+	}
+	if h := state.handlers[callbackType]; h != nil {
+		h(message, out, in)
+	}
+}
+
+// setCallback installs h for the given callback type and starts it, or
+// stops it and removes the handler if h is nil.
+func (s *Solver) setCallback(callbackType C.HighsInt, h callbackHandler) error {
+	if s.callbacks == nil && h == nil {
+		return nil
+	}
+	if err := s.installCallback(callbackType, h); err != nil {
+		return err
+	}
+	if h == nil {
+		return newError("SetCallback", Status(C.Highs_stopCallback(s.ptr, callbackType)))
+	}
+	return newError("SetCallback", Status(C.Highs_startCallback(s.ptr, callbackType)))
+}
+
+// installCallback sets the handler for the given callback type without
+// starting it, registering the HiGHS callback on first use.
+func (s *Solver) installCallback(callbackType C.HighsInt, h callbackHandler) error {
+	if s.callbacks == nil {
+		state := &callbackState{}
+		state.handle = cgo.NewHandle(state)
+		status := Status(C.gohighs_setCallback(s.ptr, C.uintptr_t(state.handle)))
+		if err := newError("SetCallback", status); err != nil {
+			state.handle.Delete()
+			return err
+		}
+		s.callbacks = state
+	}
+	s.callbacks.handlers[callbackType] = h
+	return nil
+}
+
+// stopCallbacks stops and removes every handler except the logging one,
+// which runLogged manages itself.
+func (s *Solver) stopCallbacks() error {
+	if s.callbacks == nil {
+		return nil
+	}
+	for callbackType, h := range s.callbacks.handlers {
+		if h == nil || callbackType == C.kHighsCallbackLogging {
+			continue
+		}
+		if err := s.setCallback(C.HighsInt(callbackType), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseCallbacks frees the callback state's handle.
+func (s *Solver) releaseCallbacks() {
+	if s.callbacks != nil {
+		s.callbacks.handle.Delete()
+		s.callbacks = nil
+		s.log = nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Progress reporting
+// ----------------------------------------------------------------------------
+
+// handle is the callbackHandler for kHighsCallbackMipInterrupt.
+func (r *progressReporter) handle(_ *C.char, out *C.HighsCallbackDataOut, _ *C.HighsCallbackDataIn) {
+	p := Progress{
+		Elapsed:   time.Duration(float64(out.running_time) * float64(time.Second)),
+		Nodes:     int64(out.mip_node_count),
+		Gap:       float64(out.mip_gap),
+		Incumbent: float64(out.mip_primal_bound),
+		Bound:     float64(out.mip_dual_bound),
+	}
+	r.observe(p)
+}
+
+// finish sends the final report using the solver's info values.
+func (r *progressReporter) finish(s *Solver, sol *Solution, maximize bool) {
+	noSolution := math.Inf(1)
+	if maximize {
+		noSolution = math.Inf(-1)
+	}
+	p := Progress{
+		Elapsed:   time.Duration(float64(C.Highs_getRunTime(s.ptr)) * float64(time.Second)),
+		Gap:       math.Inf(1),
+		Incumbent: noSolution,
+		Bound:     -noSolution,
+		Done:      true,
+	}
+	if sol != nil && sol.Populated {
+		p.Incumbent = sol.Objective
+		p.Bound = sol.Objective
+		p.Gap = 0
+	}
+	if nodes, err := s.GetInt64Info("mip_node_count"); err == nil && nodes >= 0 {
+		p.Nodes = nodes
+		if bound, err := s.GetFloatInfo("mip_dual_bound"); err == nil {
+			p.Bound = bound
+		}
+		if gap, err := s.GetFloatInfo("mip_gap"); err == nil {
+			p.Gap = gap
+		}
+	}
+	r.emit(p)
+}
+
+// setProgressReporter installs r on the MIP interrupt callback.
+func (s *Solver) setProgressReporter(r *progressReporter) error {
+	return s.setCallback(C.kHighsCallbackMipInterrupt, r.handle)
+}
+
+// setMIPProgress calls record for each improving solution and MIP log
+// line.
+func (s *Solver) setMIPProgress(record func(MIPProgress)) error {
+	for _, callbackType := range []C.HighsInt{
+		C.kHighsCallbackMipImprovingSolution,
+		C.kHighsCallbackMipLogging,
+	} {
+		improving := callbackType == C.kHighsCallbackMipImprovingSolution
+		h := func(_ *C.char, out *C.HighsCallbackDataOut, _ *C.HighsCallbackDataIn) {
+			record(MIPProgress{
+				Elapsed:   time.Duration(float64(out.running_time) * float64(time.Second)),
+				Improving: improving,
+				Objective: float64(out.mip_primal_bound),
+				Bound:     float64(out.mip_dual_bound),
+				Gap:       float64(out.mip_gap),
+				Nodes:     int64(out.mip_node_count),
+			})
+		}
+		if err := s.setCallback(callbackType, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setStopAtFirstFeasible interrupts the MIP solve at the first interrupt
+// check after an improving solution is found. Handlers already installed
+// for these callbacks still run first.
+func (s *Solver) setStopAtFirstFeasible() error {
+	var found bool
+	callbacks := []struct {
+		callbackType C.HighsInt
+		handle       func(in *C.HighsCallbackDataIn)
+	}{
+		{C.kHighsCallbackMipImprovingSolution, func(*C.HighsCallbackDataIn) { found = true }},
+		{C.kHighsCallbackMipInterrupt, func(in *C.HighsCallbackDataIn) {
+			if found {
+				in.user_interrupt = 1
+			}
+		}},
+	}
+	for _, cb := range callbacks {
+		var prev callbackHandler
+		if s.callbacks != nil {
+			prev = s.callbacks.handlers[cb.callbackType]
+		}
+		handle := cb.handle
+		h := func(message *C.char, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) {
+			if prev != nil {
+				prev(message, out, in)
+			}
+			handle(in)
+		}
+		if err := s.setCallback(cb.callbackType, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSolutionCollector appends each distinct feasible MIP solution to
+// *pool until it holds max entries. A handler already installed for the
+// callback still runs first.
+func (s *Solver) setSolutionCollector(max int, pool *[]PoolEntry) error {
+	var prev callbackHandler
+	if s.callbacks != nil {
+		prev = s.callbacks.handlers[C.kHighsCallbackMipSolution]
+	}
+	seen := make(map[string]bool)
+	h := func(message *C.char, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) {
+		if prev != nil {
+			prev(message, out, in)
+		}
+		if len(*pool) >= max || out.mip_solution == nil {
+			return
+		}
+		// Copy the values: HiGHS owns them only for the duration of the call
+		values := append([]float64(nil), unsafe.Slice((*float64)(unsafe.Pointer(out.mip_solution)), int(out.mip_solution_size))...)
+		key := string(unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(values))), 8*len(values)))
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		*pool = append(*pool, PoolEntry{Objective: float64(out.objective_function_value), ColValues: values})
+	}
+	return s.setCallback(C.kHighsCallbackMipSolution, h)
+}
+
+// ----------------------------------------------------------------------------
+// Interrupt callback
+// ----------------------------------------------------------------------------
+
+// SetInterruptCallback calls fn periodically while the simplex, IPM, and
+// MIP solvers run, stopping the solve at the next safe point once fn
+// returns true. The run then ends with ModelStatusInterrupt, keeping any
+// incumbent found so far. fn runs on the solving goroutine and must not
+// panic. A nil fn removes the callback, and a later call replaces it.
+func (s *Solver) SetInterruptCallback(fn func(data CallbackData) bool) error {
+	for _, callbackType := range []C.HighsInt{
+		C.kHighsCallbackSimplexInterrupt,
+		C.kHighsCallbackIpmInterrupt,
+		C.kHighsCallbackMipInterrupt,
+	} {
+		var h callbackHandler
+		if fn != nil {
+			mip := callbackType == C.kHighsCallbackMipInterrupt
+			h = func(_ *C.char, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) {
+				if fn(callbackDataFromC(out, mip)) {
+					in.user_interrupt = 1
+				}
+			}
+		}
+		if err := s.setCallback(callbackType, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callbackDataFromC converts HiGHS callback output to CallbackData.
+func callbackDataFromC(out *C.HighsCallbackDataOut, mip bool) CallbackData {
+	data := CallbackData{
+		Elapsed:           time.Duration(float64(out.running_time) * float64(time.Second)),
+		SimplexIterations: int(out.simplex_iteration_count),
+		IPMIterations:     int(out.ipm_iteration_count),
+		Objective:         float64(out.objective_function_value),
+		MIP:               mip,
+	}
+	if mip {
+		data.Nodes = int64(out.mip_node_count)
+		data.Incumbent = float64(out.mip_primal_bound)
+		data.Bound = float64(out.mip_dual_bound)
+		data.Gap = float64(out.mip_gap)
+	}
+	return data
+}
+
+// ----------------------------------------------------------------------------
+// Cancellation
+// ----------------------------------------------------------------------------
+
+// setContext interrupts the solve once ctx is done, checking it in the
+// simplex, IPM, and MIP interrupt callbacks. Handlers already installed
+// for these callbacks, such as a progress reporter, still run first.
+func (s *Solver) setContext(ctx context.Context) error {
+	for _, callbackType := range []C.HighsInt{
+		C.kHighsCallbackSimplexInterrupt,
+		C.kHighsCallbackIpmInterrupt,
+		C.kHighsCallbackMipInterrupt,
+	} {
+		var prev callbackHandler
+		if s.callbacks != nil {
+			prev = s.callbacks.handlers[callbackType]
+		}
+		h := func(message *C.char, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) {
+			if prev != nil {
+				prev(message, out, in)
+			}
+			if ctx.Err() != nil {
+				in.user_interrupt = 1
+			}
+		}
+		if err := s.setCallback(callbackType, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,50 +1,5 @@
-//go:build (linux || darwin) && (amd64 || arm64)
+//go:build cgo && (linux || darwin) && (amd64 || arm64)
 
-// Package highs provides Go bindings for the HiGHS linear optimization solver.
-//
-// HiGHS is a high-performance solver for linear programming (LP),
-// mixed-integer programming (MIP), and quadratic programming (QP) problems.
-//
-// This package embeds prebuilt static HiGHS libraries, so `go build` produces
-// a self-contained binary that does not require HiGHS to be installed.
-//
-// # Supported Platforms
-//
-//   - linux/amd64
-//   - linux/arm64
-//   - darwin/amd64
-//   - darwin/arm64
-//
-// # High-Level API Example
-//
-// The high-level API uses the Model struct to define optimization problems:
-//
-//	model := highs.Model{
-//		ColCosts:  []float64{1.0, 1.0},
-//		ColLower:  []float64{0.0, 0.0},
-//		ColUpper:  []float64{10.0, 10.0},
-//	}
-//	model.AddDenseRow(1.0, []float64{1.0, 1.0}, 5.0) // 1 <= x + y <= 5
-//
-//	solution, err := model.Solve()
-//	if err != nil {
-//		log.Fatal(err)
-//	}
-//	fmt.Println("Optimal values:", solution.ColValues)
-//
-// # Low-Level API Example
-//
-// The low-level API provides direct access to the HiGHS solver:
-//
-//	solver, err := highs.NewSolver()
-//	if err != nil {
-//		log.Fatal(err)
-//	}
-//	defer solver.Close()
-//
-//	solver.SetBoolOption("output_flag", false)
-//	// ... add variables and constraints
-//	solution, err := solver.Run()
 package highs
 
 /*
@@ -58,11 +13,33 @@ package highs
 #include <stdlib.h>
 #include <stdint.h>
 #include "highs_c_api.h"
+
+// gohighs_passNames passes n column (is_col != 0) or row names in a single
+// cgo call, stopping at the first error.
+static HighsInt gohighs_passNames(void* highs, int is_col, HighsInt n, char** names) {
+	HighsInt result = kHighsStatusOk;
+	for (HighsInt i = 0; i < n; i++) {
+		HighsInt status = is_col ? Highs_passColName(highs, i, names[i])
+		                         : Highs_passRowName(highs, i, names[i]);
+		if (status == kHighsStatusError) {
+			return status;
+		}
+		if (status == kHighsStatusWarning) {
+			result = status;
+		}
+	}
+	return result;
+}
 */
 import "C"
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"runtime"
+	"sort"
+	"sync"
 	"unsafe"
 )
 
@@ -70,43 +47,9 @@ import (
 type HighsInt = C.HighsInt
 
 // ----------------------------------------------------------------------------
-// Types
+// Type conversions
 // ----------------------------------------------------------------------------
 
-// VariableType specifies whether a variable is continuous, integer, etc.
-type VariableType int
-
-const (
-	// Continuous indicates a continuous variable (default).
-	Continuous VariableType = iota
-	// Integer indicates an integer variable.
-	Integer
-	// SemiContinuous indicates a semi-continuous variable.
-	SemiContinuous
-	// SemiInteger indicates a semi-integer variable.
-	SemiInteger
-	// ImplicitInteger indicates an implicit integer variable.
-	ImplicitInteger
-)
-
-// String returns a human-readable representation of the variable type.
-func (v VariableType) String() string {
-	switch v {
-	case Continuous:
-		return "Continuous"
-	case Integer:
-		return "Integer"
-	case SemiContinuous:
-		return "SemiContinuous"
-	case SemiInteger:
-		return "SemiInteger"
-	case ImplicitInteger:
-		return "ImplicitInteger"
-	default:
-		return "Unknown"
-	}
-}
-
 func (v VariableType) toC() C.HighsInt {
 	switch v {
 	case Continuous:
@@ -124,97 +67,19 @@ func (v VariableType) toC() C.HighsInt {
 	}
 }
 
-// Status represents the result status of a HiGHS operation.
-type Status int
-
-const (
-	// StatusError indicates the operation failed with an error.
-	StatusError Status = -1
-	// StatusOK indicates the operation succeeded.
-	StatusOK Status = 0
-	// StatusWarning indicates the operation succeeded with warnings.
-	StatusWarning Status = 1
-)
-
-// String returns a human-readable representation of the status.
-func (s Status) String() string {
-	switch s {
-	case StatusError:
-		return "Error"
-	case StatusOK:
-		return "OK"
-	case StatusWarning:
-		return "Warning"
+func variableTypeFromC(integrality C.HighsInt) VariableType {
+	switch integrality {
+	case C.kHighsVarTypeInteger:
+		return Integer
+	case C.kHighsVarTypeSemiContinuous:
+		return SemiContinuous
+	case C.kHighsVarTypeSemiInteger:
+		return SemiInteger
+	case C.kHighsVarTypeImplicitInteger:
+		return ImplicitInteger
 	default:
-		return "Unknown"
-	}
-}
-
-// ModelStatus represents the status of a solved model.
-type ModelStatus int
-
-const (
-	// ModelStatusNotSet indicates the model status has not been set.
-	ModelStatusNotSet ModelStatus = iota
-	// ModelStatusLoadError indicates an error loading the model.
-	ModelStatusLoadError
-	// ModelStatusModelError indicates an error in the model.
-	ModelStatusModelError
-	// ModelStatusPresolveError indicates an error during presolve.
-	ModelStatusPresolveError
-	// ModelStatusSolveError indicates an error during solve.
-	ModelStatusSolveError
-	// ModelStatusPostsolveError indicates an error during postsolve.
-	ModelStatusPostsolveError
-	// ModelStatusModelEmpty indicates the model is empty.
-	ModelStatusModelEmpty
-	// ModelStatusOptimal indicates an optimal solution was found.
-	ModelStatusOptimal
-	// ModelStatusInfeasible indicates the model is infeasible.
-	ModelStatusInfeasible
-	// ModelStatusUnboundedOrInfeasible indicates the model is unbounded or infeasible.
-	ModelStatusUnboundedOrInfeasible
-	// ModelStatusUnbounded indicates the model is unbounded.
-	ModelStatusUnbounded
-	// ModelStatusObjectiveBound indicates the objective bound was reached.
-	ModelStatusObjectiveBound
-	// ModelStatusObjectiveTarget indicates the objective target was reached.
-	ModelStatusObjectiveTarget
-	// ModelStatusTimeLimit indicates the time limit was reached.
-	ModelStatusTimeLimit
-	// ModelStatusIterationLimit indicates the iteration limit was reached.
-	ModelStatusIterationLimit
-	// ModelStatusUnknown indicates an unknown status.
-	ModelStatusUnknown
-)
-
-// String returns a human-readable representation of the model status.
-func (s ModelStatus) String() string {
-	names := []string{
-		"NotSet", "LoadError", "ModelError", "PresolveError",
-		"SolveError", "PostsolveError", "ModelEmpty", "Optimal",
-		"Infeasible", "UnboundedOrInfeasible", "Unbounded",
-		"ObjectiveBound", "ObjectiveTarget", "TimeLimit",
-		"IterationLimit", "Unknown",
-	}
-	if int(s) >= 0 && int(s) < len(names) {
-		return names[s]
+		return Continuous
 	}
-	return "Unknown"
-}
-
-// IsOptimal returns true if the model was solved to optimality.
-func (s ModelStatus) IsOptimal() bool {
-	return s == ModelStatusOptimal
-}
-
-// HasSolution returns true if the model has a valid solution.
-func (s ModelStatus) HasSolution() bool {
-	return s == ModelStatusOptimal ||
-		s == ModelStatusObjectiveBound ||
-		s == ModelStatusObjectiveTarget ||
-		s == ModelStatusTimeLimit ||
-		s == ModelStatusIterationLimit
 }
 
 func modelStatusFromC(status C.HighsInt) ModelStatus {
@@ -249,45 +114,15 @@ func modelStatusFromC(status C.HighsInt) ModelStatus {
 		return ModelStatusTimeLimit
 	case C.kHighsModelStatusIterationLimit:
 		return ModelStatusIterationLimit
+	case C.kHighsModelStatusSolutionLimit:
+		return ModelStatusSolutionLimit
+	case C.kHighsModelStatusInterrupt:
+		return ModelStatusInterrupt
 	default:
 		return ModelStatusUnknown
 	}
 }
 
-// BasisStatus represents the basis status of a variable or constraint.
-type BasisStatus int
-
-const (
-	// BasisStatusLower indicates the variable is at its lower bound.
-	BasisStatusLower BasisStatus = iota
-	// BasisStatusBasic indicates the variable is basic.
-	BasisStatusBasic
-	// BasisStatusUpper indicates the variable is at its upper bound.
-	BasisStatusUpper
-	// BasisStatusZero indicates the variable is free and set to zero.
-	BasisStatusZero
-	// BasisStatusNonbasic indicates the variable is nonbasic.
-	BasisStatusNonbasic
-)
-
-// String returns a human-readable representation of the basis status.
-func (s BasisStatus) String() string {
-	switch s {
-	case BasisStatusLower:
-		return "Lower"
-	case BasisStatusBasic:
-		return "Basic"
-	case BasisStatusUpper:
-		return "Upper"
-	case BasisStatusZero:
-		return "Zero"
-	case BasisStatusNonbasic:
-		return "Nonbasic"
-	default:
-		return "Unknown"
-	}
-}
-
 func basisStatusFromC(status C.HighsInt) BasisStatus {
 	switch status {
 	case C.kHighsBasisStatusLower:
@@ -305,44 +140,57 @@ func basisStatusFromC(status C.HighsInt) BasisStatus {
 	}
 }
 
-// Nonzero represents a non-zero entry in a sparse matrix.
-// Row and Col are zero-indexed.
-type Nonzero struct {
-	Row int
-	Col int
-	Val float64
+func (b BasisStatus) toC() C.HighsInt {
+	switch b {
+	case BasisStatusBasic:
+		return C.kHighsBasisStatusBasic
+	case BasisStatusUpper:
+		return C.kHighsBasisStatusUpper
+	case BasisStatusZero:
+		return C.kHighsBasisStatusZero
+	case BasisStatusNonbasic:
+		return C.kHighsBasisStatusNonbasic
+	default:
+		return C.kHighsBasisStatusLower
+	}
+}
+
+func (f MatrixFormat) toC() C.HighsInt {
+	if f == MatrixFormatColwise {
+		return C.kHighsMatrixFormatColwise
+	}
+	return C.kHighsMatrixFormatRowwise
 }
 
 // ----------------------------------------------------------------------------
-// Errors
+// Version
 // ----------------------------------------------------------------------------
 
-// Error represents a HiGHS error with context about which operation failed.
-type Error struct {
-	Op     string // Operation that failed (e.g., "Solve", "SetOption")
-	Status Status // HiGHS status code
-	Msg    string // Additional context
-}
+// version caches the library version, which is fixed at link time.
+var version = sync.OnceValue(func() string {
+	return C.GoString(C.Highs_version())
+})
 
-func (e *Error) Error() string {
-	if e.Msg != "" {
-		return fmt.Sprintf("highs: %s failed: %s", e.Op, e.Msg)
-	}
-	return fmt.Sprintf("highs: %s failed with status %s", e.Op, e.Status)
+// Version returns the version of the embedded HiGHS library (e.g. "1.12.0").
+func Version() string {
+	return version()
 }
 
-// newError creates a new Error if status is not OK.
-// Returns nil if status is OK or Warning.
-func newError(op string, status Status) error {
-	if status == StatusOK || status == StatusWarning {
-		return nil
-	}
-	return &Error{Op: op, Status: status}
-}
+// infinity caches the library's infinity value, which is fixed at link time.
+var infinity = sync.OnceValue(func() float64 {
+	ptr := C.Highs_create()
+	defer C.Highs_destroy(ptr)
+	return float64(C.Highs_getInfinity(ptr))
+})
 
-// newErrorMsg creates a new Error with an additional message.
-func newErrorMsg(op, msg string) error {
-	return &Error{Op: op, Status: StatusError, Msg: msg}
+// InfinityValue returns the value HiGHS uses to represent infinity.
+//
+// The embedded HiGHS reports math.Inf(1). Separately, HiGHS treats any
+// bound whose magnitude is at least 1e20 (its infinite_bound option) as
+// infinite, so conventions such as 1e30 for "unbounded" behave the same as
+// math.Inf. Values reported back by HiGHS use math.Inf, not 1e30.
+func InfinityValue() float64 {
+	return infinity()
 }
 
 // ----------------------------------------------------------------------------
@@ -359,6 +207,28 @@ func newErrorMsg(op, msg string) error {
 //	defer solver.Close()
 type Solver struct {
 	ptr unsafe.Pointer
+
+	// Scratch space reused by RunInto so repeated solves do not allocate.
+	colBasis    []C.HighsInt
+	rowBasis    []C.HighsInt
+	infoScratch C.HighsInt
+
+	trackObjective   bool
+	objectiveHistory []float64
+
+	// infinity caches Infinity; zero means not yet retrieved.
+	infinity float64
+
+	solutionFilter func(values []float64) bool
+
+	// hessianConvexity holds the convexity of the Hessian when minimizing
+	// and when maximizing, checked once by PassHessian so that Run need
+	// not inspect the model.
+	hessianConvexity [2]convexity
+
+	// callbacks is created when the first callback handler is set.
+	callbacks *callbackState
+	log       *runLog
 }
 
 // NewSolver creates a new HiGHS solver instance.
@@ -376,6 +246,20 @@ func NewSolver() (*Solver, error) {
 	return s, nil
 }
 
+// NewSolverNoFinalizer creates a new HiGHS solver instance without
+// registering a runtime finalizer.
+//
+// This avoids per-instance GC overhead in services that create or pool
+// many solvers. WARNING: the native HiGHS instance is never released
+// automatically; the caller must call Close or the memory will leak.
+func NewSolverNoFinalizer() (*Solver, error) {
+	ptr := C.Highs_create()
+	if ptr == nil {
+		return nil, newErrorMsg("NewSolverNoFinalizer", "failed to create HiGHS instance")
+	}
+	return &Solver{ptr: ptr}, nil
+}
+
 // Close releases the resources held by the solver.
 // It is safe to call Close multiple times.
 func (s *Solver) Close() {
@@ -383,17 +267,20 @@ func (s *Solver) Close() {
 		C.Highs_destroy(s.ptr)
 		s.ptr = nil
 	}
+	s.releaseCallbacks()
 }
 
 // Clear resets the solver to its initial state, clearing
 // the model and resetting options to defaults.
 func (s *Solver) Clear() error {
+	s.hessianConvexity = [2]convexity{}
 	status := Status(C.Highs_clear(s.ptr))
 	return newError("Clear", status)
 }
 
 // ClearModel removes all variables and constraints but keeps options.
 func (s *Solver) ClearModel() error {
+	s.hessianConvexity = [2]convexity{}
 	status := Status(C.Highs_clearModel(s.ptr))
 	return newError("ClearModel", status)
 }
@@ -404,8 +291,26 @@ func (s *Solver) ClearSolver() error {
 	return newError("ClearSolver", status)
 }
 
+// ResetOptions resets all options to their defaults without touching the
+// loaded model, unlike Clear. It uses HiGHS's own option reset, which
+// leaves the model and solution in place, so the same model can be
+// solved under a clean baseline for each of several configurations.
+func (s *Solver) ResetOptions() error {
+	status := Status(C.Highs_resetOptions(s.ptr))
+	return newError("ResetOptions", status)
+}
+
 // Infinity returns the value used by HiGHS to represent infinity.
+// The value is retrieved once and cached; see InfinityValue.
 func (s *Solver) Infinity() float64 {
+	if s.infinity == 0 {
+		s.infinity = s.getInfinity()
+	}
+	return s.infinity
+}
+
+// getInfinity reads the infinity value from the HiGHS instance.
+func (s *Solver) getInfinity() float64 {
 	return float64(C.Highs_getInfinity(s.ptr))
 }
 
@@ -505,6 +410,112 @@ func (s *Solver) GetFloatOption(name string) (float64, error) {
 	return float64(val), nil
 }
 
+// GetStringOption returns the value of a string option.
+func (s *Solver) GetStringOption(name string) (string, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var buf [C.kHighsMaximumStringLength]C.char
+	status := Status(C.Highs_getStringOptionValue(s.ptr, cName, &buf[0]))
+	if err := newError("GetStringOption", status); err != nil {
+		return "", err
+	}
+	return C.GoString(&buf[0]), nil
+}
+
+// OptionType returns the type of value the named option takes, for
+// example to pick the setter for a value given as text.
+func (s *Solver) OptionType(name string) (OptionType, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var optionType C.HighsInt
+	status := Status(C.Highs_getOptionType(s.ptr, cName, &optionType))
+	if err := newError("OptionType", status); err != nil {
+		return 0, err
+	}
+	switch optionType {
+	case C.kHighsOptionTypeBool:
+		return OptionTypeBool, nil
+	case C.kHighsOptionTypeInt:
+		return OptionTypeInt, nil
+	case C.kHighsOptionTypeDouble:
+		return OptionTypeDouble, nil
+	default:
+		return OptionTypeString, nil
+	}
+}
+
+// IntOptionInfo returns the current value, default, and allowed range of
+// the named integer option.
+func (s *Solver) IntOptionInfo(name string) (current, def, min, max int, err error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cCurrent, cMin, cMax, cDefault C.HighsInt
+	status := Status(C.Highs_getIntOptionValues(s.ptr, cName, &cCurrent, &cMin, &cMax, &cDefault))
+	if err := newError("IntOptionInfo", status); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return int(cCurrent), int(cDefault), int(cMin), int(cMax), nil
+}
+
+// FloatOptionInfo returns the current value, default, and allowed range
+// of the named double option.
+func (s *Solver) FloatOptionInfo(name string) (current, def, min, max float64, err error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cCurrent, cMin, cMax, cDefault C.double
+	status := Status(C.Highs_getDoubleOptionValues(s.ptr, cName, &cCurrent, &cMin, &cMax, &cDefault))
+	if err := newError("FloatOptionInfo", status); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return float64(cCurrent), float64(cDefault), float64(cMin), float64(cMax), nil
+}
+
+// optionsFingerprintLen is the number of hex digits kept by
+// OptionsFingerprint.
+const optionsFingerprintLen = 16
+
+// OptionsFingerprint returns a short hex digest of the current value of
+// every HiGHS option. Solvers configured identically have the same
+// fingerprint, so comparing fingerprints shows whether differing results
+// may stem from differing options.
+func (s *Solver) OptionsFingerprint() (string, error) {
+	h := sha256.New()
+	numOptions := int(C.Highs_getNumOptions(s.ptr))
+	for i := 0; i < numOptions; i++ {
+		var cName *C.char
+		status := Status(C.Highs_getOptionName(s.ptr, C.HighsInt(i), &cName))
+		if err := newError("OptionsFingerprint", status); err != nil {
+			return "", err
+		}
+		name := C.GoString(cName)
+		C.free(unsafe.Pointer(cName))
+		optionType, err := s.OptionType(name)
+		if err != nil {
+			return "", err
+		}
+		var value any
+		switch optionType {
+		case OptionTypeBool:
+			value, err = s.GetBoolOption(name)
+		case OptionTypeInt:
+			value, err = s.GetIntOption(name)
+		case OptionTypeDouble:
+			value, err = s.GetFloatOption(name)
+		default:
+			value, err = s.GetStringOption(name)
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s=%v\n", name, value)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:optionsFingerprintLen], nil
+}
+
 // SetMaximize sets whether to maximize (true) or minimize (false).
 func (s *Solver) SetMaximize(maximize bool) error {
 	sense := C.kHighsObjSenseMinimize
@@ -515,16 +526,40 @@ func (s *Solver) SetMaximize(maximize bool) error {
 	return newError("SetMaximize", status)
 }
 
+// ObjectiveSense reports whether the loaded model is maximized.
+func (s *Solver) ObjectiveSense() (maximize bool, err error) {
+	var sense C.HighsInt
+	status := Status(C.Highs_getObjectiveSense(s.ptr, &sense))
+	if err := newError("ObjectiveSense", status); err != nil {
+		return false, err
+	}
+	return sense == C.kHighsObjSenseMaximize, nil
+}
+
+// ObjectiveValue returns the objective value of the current solution,
+// without fetching the rest of the solution as Run does.
+func (s *Solver) ObjectiveValue() float64 {
+	return float64(C.Highs_getObjectiveValue(s.ptr))
+}
+
 // SetObjectiveOffset sets a constant offset for the objective function.
 func (s *Solver) SetObjectiveOffset(offset float64) error {
 	status := Status(C.Highs_changeObjectiveOffset(s.ptr, C.double(offset)))
 	return newError("SetObjectiveOffset", status)
 }
 
-// AddVar adds a single variable with the given bounds.
-func (s *Solver) AddVar(lower, upper float64) error {
+// AddVar adds a single variable with the given bounds and returns its
+// column index, or -1 on error.
+func (s *Solver) AddVar(lower, upper float64) (int, error) {
+	if msg := boundsError(lower, upper); msg != "" {
+		return -1, newErrorMsg("AddVar", msg)
+	}
+	col := s.NumCol()
 	status := Status(C.Highs_addVar(s.ptr, C.double(lower), C.double(upper)))
-	return newError("AddVar", status)
+	if err := newError("AddVar", status); err != nil {
+		return -1, err
+	}
+	return col, nil
 }
 
 // AddVars adds multiple variables with the given bounds.
@@ -535,6 +570,11 @@ func (s *Solver) AddVars(lower, upper []float64) error {
 	if len(lower) == 0 {
 		return nil
 	}
+	for i := range lower {
+		if msg := boundsError(lower[i], upper[i]); msg != "" {
+			return newErrorMsg("AddVars", fmt.Sprintf("column %d: %s", i, msg))
+		}
+	}
 
 	status := Status(C.Highs_addVars(s.ptr,
 		C.HighsInt(len(lower)),
@@ -549,12 +589,19 @@ func (s *Solver) AddRow(lower, upper float64, index []int, value []float64) erro
 	if len(index) != len(value) {
 		return newErrorMsg("AddRow", "index and value must have same length")
 	}
+	if msg := boundsError(lower, upper); msg != "" {
+		return newErrorMsg("AddRow", msg)
+	}
 
+	numCol := s.NumCol()
 	var pIndex *C.HighsInt
 	var pValue *C.double
 	if len(index) > 0 {
 		cIndex := make([]C.HighsInt, len(index))
 		for i, v := range index {
+			if v < 0 || v >= numCol {
+				return newErrorMsg("AddRow", fmt.Sprintf("column index %d out of range [0, %d)", v, numCol))
+			}
 			cIndex[i] = C.HighsInt(v)
 		}
 		pIndex = &cIndex[0]
@@ -568,6 +615,10 @@ func (s *Solver) AddRow(lower, upper float64, index []int, value []float64) erro
 }
 
 // AddRows adds multiple constraints in compressed sparse row format.
+//
+// starts holds one entry per row: row i uses index[starts[i]:starts[i+1]]
+// (the last row runs to the end of index). A row whose start equals the
+// next row's start is empty, which is valid, e.g. for a free placeholder row.
 func (s *Solver) AddRows(lower, upper []float64, starts, index []int, value []float64) error {
 	if len(lower) != len(upper) {
 		return newErrorMsg("AddRows", "lower and upper bounds must have same length")
@@ -578,13 +629,30 @@ func (s *Solver) AddRows(lower, upper []float64, starts, index []int, value []fl
 	if len(lower) == 0 {
 		return nil
 	}
+	if len(starts) != len(lower) {
+		return newErrorMsg("AddRows", fmt.Sprintf("starts has length %d, expected %d", len(starts), len(lower)))
+	}
+	for i := range lower {
+		if msg := boundsError(lower[i], upper[i]); msg != "" {
+			return newErrorMsg("AddRows", fmt.Sprintf("row %d: %s", i, msg))
+		}
+	}
 
 	cStarts := make([]C.HighsInt, len(starts))
+	prev := 0
 	for i, v := range starts {
+		if (i == 0 && v != 0) || v < prev || v > len(index) {
+			return newErrorMsg("AddRows", fmt.Sprintf("invalid start %d for row %d", v, i))
+		}
 		cStarts[i] = C.HighsInt(v)
+		prev = v
 	}
+	numCol := s.NumCol()
 	cIndex := make([]C.HighsInt, len(index))
 	for i, v := range index {
+		if v < 0 || v >= numCol {
+			return newErrorMsg("AddRows", fmt.Sprintf("column index %d out of range [0, %d)", v, numCol))
+		}
 		cIndex[i] = C.HighsInt(v)
 	}
 
@@ -603,6 +671,171 @@ func (s *Solver) AddRows(lower, upper []float64, starts, index []int, value []fl
 	return newError("AddRows", status)
 }
 
+// AddCol adds a variable together with its coefficients in existing
+// rows, given as row indices and values: the column counterpart of AddRow,
+// as needed for column generation and branch-and-price.
+func (s *Solver) AddCol(cost, lower, upper float64, rows []int, values []float64) error {
+	if len(rows) != len(values) {
+		return newErrorMsg("AddCol", "rows and values must have same length")
+	}
+	if msg := boundsError(lower, upper); msg != "" {
+		return newErrorMsg("AddCol", msg)
+	}
+
+	numRow := s.NumRow()
+	var pIndex *C.HighsInt
+	var pValue *C.double
+	if len(rows) > 0 {
+		cIndex := make([]C.HighsInt, len(rows))
+		for i, v := range rows {
+			if v < 0 || v >= numRow {
+				return newErrorMsg("AddCol", fmt.Sprintf("row index %d out of range [0, %d)", v, numRow))
+			}
+			cIndex[i] = C.HighsInt(v)
+		}
+		pIndex = &cIndex[0]
+		pValue = (*C.double)(&values[0])
+	}
+
+	status := Status(C.Highs_addCol(s.ptr,
+		C.double(cost), C.double(lower), C.double(upper),
+		C.HighsInt(len(rows)), pIndex, pValue))
+	return newError("AddCol", status)
+}
+
+// AddCols adds multiple variables, with their costs, bounds, and
+// coefficients in existing rows, in compressed sparse column format.
+//
+// starts holds one entry per column: column j uses index[starts[j]:starts[j+1]]
+// (the last column runs to the end of index), where index holds row
+// indices. It mirrors AddRows.
+func (s *Solver) AddCols(cost, lower, upper []float64, starts, index []int, value []float64) error {
+	if len(cost) != len(lower) || len(lower) != len(upper) {
+		return newErrorMsg("AddCols", "cost, lower and upper must have same length")
+	}
+	if len(index) != len(value) {
+		return newErrorMsg("AddCols", "index and value must have same length")
+	}
+	if len(lower) == 0 {
+		return nil
+	}
+	if len(starts) != len(lower) {
+		return newErrorMsg("AddCols", fmt.Sprintf("starts has length %d, expected %d", len(starts), len(lower)))
+	}
+	for i := range lower {
+		if msg := boundsError(lower[i], upper[i]); msg != "" {
+			return newErrorMsg("AddCols", fmt.Sprintf("column %d: %s", i, msg))
+		}
+	}
+
+	cStarts := make([]C.HighsInt, len(starts))
+	prev := 0
+	for i, v := range starts {
+		if (i == 0 && v != 0) || v < prev || v > len(index) {
+			return newErrorMsg("AddCols", fmt.Sprintf("invalid start %d for column %d", v, i))
+		}
+		cStarts[i] = C.HighsInt(v)
+		prev = v
+	}
+	numRow := s.NumRow()
+	cIndex := make([]C.HighsInt, len(index))
+	for i, v := range index {
+		if v < 0 || v >= numRow {
+			return newErrorMsg("AddCols", fmt.Sprintf("row index %d out of range [0, %d)", v, numRow))
+		}
+		cIndex[i] = C.HighsInt(v)
+	}
+
+	var pIndex *C.HighsInt
+	var pValue *C.double
+	if len(index) > 0 {
+		pIndex = &cIndex[0]
+		pValue = (*C.double)(&value[0])
+	}
+
+	status := Status(C.Highs_addCols(s.ptr,
+		C.HighsInt(len(lower)),
+		(*C.double)(&cost[0]), (*C.double)(&lower[0]), (*C.double)(&upper[0]),
+		C.HighsInt(len(value)),
+		&cStarts[0], pIndex, pValue))
+	return newError("AddCols", status)
+}
+
+// DeleteColsByRange deletes the columns from through to (inclusive).
+// Later columns shift down to fill the gap, so column j > to becomes
+// column j-(to-from+1).
+func (s *Solver) DeleteColsByRange(from, to int) error {
+	numCol := s.NumCol()
+	if from < 0 || to >= numCol || from > to {
+		return newErrorMsg("DeleteColsByRange", fmt.Sprintf("invalid column range [%d, %d] for %d columns", from, to, numCol))
+	}
+	s.hessianConvexity = [2]convexity{}
+	status := Status(C.Highs_deleteColsByRange(s.ptr, C.HighsInt(from), C.HighsInt(to)))
+	return newError("DeleteColsByRange", status)
+}
+
+// DeleteColsBySet deletes the given columns, which may be in any order
+// but must not repeat. The remaining columns keep their relative order
+// and are renumbered consecutively from zero.
+func (s *Solver) DeleteColsBySet(cols []int) error {
+	if len(cols) == 0 {
+		return nil
+	}
+	set, err := indexSet("DeleteColsBySet", "column", cols, s.NumCol())
+	if err != nil {
+		return err
+	}
+	s.hessianConvexity = [2]convexity{}
+	status := Status(C.Highs_deleteColsBySet(s.ptr, C.HighsInt(len(set)), &set[0]))
+	return newError("DeleteColsBySet", status)
+}
+
+// DeleteRowsByRange deletes the rows from through to (inclusive).
+// Later rows shift down to fill the gap, so row j > to becomes
+// row j-(to-from+1).
+func (s *Solver) DeleteRowsByRange(from, to int) error {
+	numRow := s.NumRow()
+	if from < 0 || to >= numRow || from > to {
+		return newErrorMsg("DeleteRowsByRange", fmt.Sprintf("invalid row range [%d, %d] for %d rows", from, to, numRow))
+	}
+	status := Status(C.Highs_deleteRowsByRange(s.ptr, C.HighsInt(from), C.HighsInt(to)))
+	return newError("DeleteRowsByRange", status)
+}
+
+// DeleteRowsBySet deletes the given rows, which may be in any order but
+// must not repeat. The remaining rows keep their relative order and are
+// renumbered consecutively from zero.
+func (s *Solver) DeleteRowsBySet(rows []int) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	set, err := indexSet("DeleteRowsBySet", "row", rows, s.NumRow())
+	if err != nil {
+		return err
+	}
+	status := Status(C.Highs_deleteRowsBySet(s.ptr, C.HighsInt(len(set)), &set[0]))
+	return newError("DeleteRowsBySet", status)
+}
+
+// indexSet converts indices into the increasing, duplicate-free set
+// HiGHS expects, checking that each lies in [0, n).
+func indexSet(op, kind string, indices []int, n int) ([]C.HighsInt, error) {
+	set := make([]C.HighsInt, len(indices))
+	for i, v := range indices {
+		if v < 0 || v >= n {
+			return nil, newErrorMsg(op, fmt.Sprintf("%s index %d out of range [0, %d)", kind, v, n))
+		}
+		set[i] = C.HighsInt(v)
+	}
+	sort.Slice(set, func(i, j int) bool { return set[i] < set[j] })
+	for i := 1; i < len(set); i++ {
+		if set[i] == set[i-1] {
+			return nil, newErrorMsg(op, fmt.Sprintf("%s index %d repeated", kind, set[i]))
+		}
+	}
+	return set, nil
+}
+
 // SetColCost sets the objective coefficient for a column.
 func (s *Solver) SetColCost(col int, cost float64) error {
 	status := Status(C.Highs_changeColCost(s.ptr, C.HighsInt(col), C.double(cost)))
@@ -620,37 +853,401 @@ func (s *Solver) SetColCosts(costs []float64) error {
 	return newError("SetColCosts", status)
 }
 
+// SetColCostsBySet sets the objective coefficients of the given columns,
+// with costs[i] applying to cols[i], leaving all other costs unchanged.
+// The columns may be in any order but must not repeat.
+func (s *Solver) SetColCostsBySet(cols []int, costs []float64) error {
+	if len(cols) != len(costs) {
+		return newErrorMsg("SetColCostsBySet", fmt.Sprintf("cols and costs must have equal length, got %d and %d", len(cols), len(costs)))
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+	set, err := indexSet("SetColCostsBySet", "column", cols, s.NumCol())
+	if err != nil {
+		return err
+	}
+
+	// HiGHS expects the costs in the order of the sorted set
+	byCol := make(map[int]float64, len(cols))
+	for i, col := range cols {
+		byCol[col] = costs[i]
+	}
+	sorted := make([]float64, len(set))
+	for i, col := range set {
+		sorted[i] = byCol[int(col)]
+	}
+	status := Status(C.Highs_changeColsCostBySet(s.ptr, C.HighsInt(len(set)), &set[0], (*C.double)(&sorted[0])))
+	return newError("SetColCostsBySet", status)
+}
+
 // SetColBounds sets the bounds for a column.
 func (s *Solver) SetColBounds(col int, lower, upper float64) error {
+	if msg := boundsError(lower, upper); msg != "" {
+		return newErrorMsg("SetColBounds", msg)
+	}
 	status := Status(C.Highs_changeColBounds(s.ptr,
 		C.HighsInt(col), C.double(lower), C.double(upper)))
 	return newError("SetColBounds", status)
 }
 
-// SetColIntegrality sets the variable type for a column.
-func (s *Solver) SetColIntegrality(col int, varType VariableType) error {
-	status := Status(C.Highs_changeColIntegrality(s.ptr,
-		C.HighsInt(col), varType.toC()))
-	return newError("SetColIntegrality", status)
+// SetColBoundsRange sets the bounds of the columns from through to
+// (inclusive) in a single call, with lower[i] and upper[i] applying to
+// column from+i. This is cheaper than calling SetColBounds per column when
+// re-solving with many changed bounds.
+func (s *Solver) SetColBoundsRange(from, to int, lower, upper []float64) error {
+	numCol := s.NumCol()
+	if from < 0 || to >= numCol || from > to {
+		return newErrorMsg("SetColBoundsRange", fmt.Sprintf("invalid column range [%d, %d] for %d columns", from, to, numCol))
+	}
+	if n := to - from + 1; len(lower) != n || len(upper) != n {
+		return newErrorMsg("SetColBoundsRange", fmt.Sprintf("lower and upper must have length %d, got %d and %d", n, len(lower), len(upper)))
+	}
+	for i := range lower {
+		if msg := boundsError(lower[i], upper[i]); msg != "" {
+			return newErrorMsg("SetColBoundsRange", fmt.Sprintf("column %d: %s", from+i, msg))
+		}
+	}
+
+	status := Status(C.Highs_changeColsBoundsByRange(s.ptr,
+		C.HighsInt(from), C.HighsInt(to),
+		(*C.double)(&lower[0]),
+		(*C.double)(&upper[0])))
+	return newError("SetColBoundsRange", status)
 }
 
-// SetIntegrality sets the variable types for a range of columns.
-func (s *Solver) SetIntegrality(varTypes []VariableType) error {
-	if len(varTypes) == 0 {
-		return nil
+// SetRowBounds sets the bounds for a row.
+func (s *Solver) SetRowBounds(row int, lower, upper float64) error {
+	if msg := boundsError(lower, upper); msg != "" {
+		return newErrorMsg("SetRowBounds", msg)
 	}
-	integrality := make([]C.HighsInt, len(varTypes))
-	for i, vt := range varTypes {
-		integrality[i] = vt.toC()
+	status := Status(C.Highs_changeRowBounds(s.ptr,
+		C.HighsInt(row), C.double(lower), C.double(upper)))
+	return newError("SetRowBounds", status)
+}
+
+// SetRowBoundsRange sets the bounds of the rows from through to
+// (inclusive) in a single call, with lower[i] and upper[i] applying to row
+// from+i, for example to sweep right-hand sides while re-solving one
+// model.
+func (s *Solver) SetRowBoundsRange(from, to int, lower, upper []float64) error {
+	numRow := s.NumRow()
+	if from < 0 || to >= numRow || from > to {
+		return newErrorMsg("SetRowBoundsRange", fmt.Sprintf("invalid row range [%d, %d] for %d rows", from, to, numRow))
 	}
-	status := Status(C.Highs_changeColsIntegralityByRange(s.ptr,
-		0, C.HighsInt(len(varTypes)-1),
-		&integrality[0]))
-	return newError("SetIntegrality", status)
+	if n := to - from + 1; len(lower) != n || len(upper) != n {
+		return newErrorMsg("SetRowBoundsRange", fmt.Sprintf("lower and upper must have length %d, got %d and %d", n, len(lower), len(upper)))
+	}
+	for i := range lower {
+		if msg := boundsError(lower[i], upper[i]); msg != "" {
+			return newErrorMsg("SetRowBoundsRange", fmt.Sprintf("row %d: %s", from+i, msg))
+		}
+	}
+
+	status := Status(C.Highs_changeRowsBoundsByRange(s.ptr,
+		C.HighsInt(from), C.HighsInt(to),
+		(*C.double)(&lower[0]),
+		(*C.double)(&upper[0])))
+	return newError("SetRowBoundsRange", status)
 }
 
-// PassModel passes a complete model to the solver in one call.
+// GetCoeff returns the constraint matrix coefficient at row and col,
+// which is 0 for a position without an entry. The HiGHS C API has no
+// direct accessor, so the coefficient is looked up in the row's entries.
+func (s *Solver) GetCoeff(row, col int) (float64, error) {
+	if numRow, numCol := s.NumRow(), s.NumCol(); row < 0 || row >= numRow || col < 0 || col >= numCol {
+		return 0, newErrorMsg("GetCoeff", fmt.Sprintf("entry (%d, %d) out of range for %d rows and %d columns", row, col, numRow, numCol))
+	}
+	_, _, cols, values, err := s.GetRow(row)
+	if err != nil {
+		return 0, err
+	}
+	for i, c := range cols {
+		if c == col {
+			return values[i], nil
+		}
+	}
+	return 0, nil
+}
+
+// ChangeCoeff sets the constraint matrix coefficient at row and col to
+// value, where a zero value removes the entry. Use ChangeCoeffs to
+// change many coefficients at once.
+func (s *Solver) ChangeCoeff(row, col int, value float64) error {
+	if numRow, numCol := s.NumRow(), s.NumCol(); row < 0 || row >= numRow || col < 0 || col >= numCol {
+		return newErrorMsg("ChangeCoeff", fmt.Sprintf("entry (%d, %d) out of range for %d rows and %d columns", row, col, numRow, numCol))
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return newErrorMsg("ChangeCoeff", fmt.Sprintf("entry (%d, %d) has non-finite value %v", row, col, value))
+	}
+	status := Status(C.Highs_changeCoeff(s.ptr, C.HighsInt(row), C.HighsInt(col), C.double(value)))
+	return newError("ChangeCoeff", status)
+}
+
+// changeCoeffsRebuildMin and changeCoeffsRebuildFraction set when
+// ChangeCoeffs rebuilds the model instead of editing it in place: the
+// batch must have at least changeCoeffsRebuildMin entries and at least
+// changeCoeffsRebuildFraction times as many as the matrix has nonzeros.
+const (
+	changeCoeffsRebuildMin      = 64
+	changeCoeffsRebuildFraction = 0.1
+)
+
+// ChangeCoeffs sets the constraint matrix coefficients at the given
+// positions to the given values, where a zero value removes the entry.
+// If a position appears more than once, the last value wins. Small
+// batches are applied one coefficient at a time; large ones rebuild the
+// model in a single pass, since HiGHS shifts the whole matrix for every
+// inserted entry. The rebuild keeps row and column names, but like any
+// model change it discards the current solution, and it also discards
+// the basis. Entries are validated before any change is made.
+func (s *Solver) ChangeCoeffs(entries []Nonzero) error {
+	numRow, numCol := s.NumRow(), s.NumCol()
+	for _, nz := range entries {
+		if nz.Row < 0 || nz.Row >= numRow || nz.Col < 0 || nz.Col >= numCol {
+			return newErrorMsg("ChangeCoeffs", fmt.Sprintf("entry (%d, %d) out of range for %d rows and %d columns", nz.Row, nz.Col, numRow, numCol))
+		}
+		if math.IsNaN(nz.Val) || math.IsInf(nz.Val, 0) {
+			return newErrorMsg("ChangeCoeffs", fmt.Sprintf("entry (%d, %d) has non-finite value %v", nz.Row, nz.Col, nz.Val))
+		}
+	}
+	if len(entries) >= changeCoeffsRebuildMin &&
+		float64(len(entries)) >= changeCoeffsRebuildFraction*float64(s.NumNonzero()) {
+		return s.rebuildCoeffs(entries)
+	}
+	return s.changeCoeffsEach(entries)
+}
+
+// changeCoeffsEach applies entries with one Highs_changeCoeff call each.
+func (s *Solver) changeCoeffsEach(entries []Nonzero) error {
+	for _, nz := range entries {
+		status := Status(C.Highs_changeCoeff(s.ptr, C.HighsInt(nz.Row), C.HighsInt(nz.Col), C.double(nz.Val)))
+		if err := newError("ChangeCoeffs", status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildCoeffs applies entries by reading the model, editing its matrix,
+// and passing it back, restoring any names afterwards.
+func (s *Solver) rebuildCoeffs(entries []Nonzero) error {
+	m, err := s.GetModel()
+	if err != nil {
+		return err
+	}
+	colNames, colErr := s.ColNames()
+	rowNames, rowErr := s.RowNames()
+
+	type position struct{ row, col int }
+	edits := make(map[position]float64, len(entries))
+	for _, nz := range entries {
+		edits[position{nz.Row, nz.Col}] = nz.Val
+	}
+	matrix := m.ConstMatrix[:0]
+	for _, nz := range m.ConstMatrix {
+		if _, ok := edits[position{nz.Row, nz.Col}]; !ok {
+			matrix = append(matrix, nz)
+		}
+	}
+	for pos, val := range edits {
+		if val != 0 {
+			matrix = append(matrix, Nonzero{Row: pos.row, Col: pos.col, Val: val})
+		}
+	}
+	m.ConstMatrix = matrix
+
+	if err := m.load(s); err != nil {
+		return err
+	}
+	if colErr == nil {
+		if err := s.setNames("ChangeCoeffs", true, len(colNames), colNames, false); err != nil {
+			return err
+		}
+	}
+	if rowErr == nil {
+		if err := s.setNames("ChangeCoeffs", false, len(rowNames), rowNames, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetColIntegrality sets the variable type for a column.
+func (s *Solver) SetColIntegrality(col int, varType VariableType) error {
+	status := Status(C.Highs_changeColIntegrality(s.ptr,
+		C.HighsInt(col), varType.toC()))
+	return newError("SetColIntegrality", status)
+}
+
+// SetIntegrality sets the variable types for a range of columns.
+func (s *Solver) SetIntegrality(varTypes []VariableType) error {
+	if len(varTypes) == 0 {
+		return nil
+	}
+	integrality := make([]C.HighsInt, len(varTypes))
+	for i, vt := range varTypes {
+		integrality[i] = vt.toC()
+	}
+	status := Status(C.Highs_changeColsIntegralityByRange(s.ptr,
+		0, C.HighsInt(len(varTypes)-1),
+		&integrality[0]))
+	return newError("SetIntegrality", status)
+}
+
+// AllContinuous marks every column as continuous, turning a MIP into
+// its LP relaxation.
+func (s *Solver) AllContinuous() error {
+	return s.setAllIntegrality("AllContinuous", Continuous)
+}
+
+// AllInteger marks every column as integer.
+func (s *Solver) AllInteger() error {
+	return s.setAllIntegrality("AllInteger", Integer)
+}
+
+func (s *Solver) setAllIntegrality(op string, varType VariableType) error {
+	numCol := s.NumCol()
+	if numCol == 0 {
+		return nil
+	}
+	integrality := make([]C.HighsInt, numCol)
+	for i := range integrality {
+		integrality[i] = varType.toC()
+	}
+	status := Status(C.Highs_changeColsIntegralityByRange(s.ptr,
+		0, C.HighsInt(numCol-1),
+		&integrality[0]))
+	return newError(op, status)
+}
+
+// Integralities returns the variable type of every column.
+func (s *Solver) Integralities() ([]VariableType, error) {
+	varTypes := make([]VariableType, s.NumCol())
+	for col := range varTypes {
+		var integrality C.HighsInt
+		status := Status(C.Highs_getColIntegrality(s.ptr, C.HighsInt(col), &integrality))
+		if err := newError("Integralities", status); err != nil {
+			return nil, err
+		}
+		varTypes[col] = variableTypeFromC(integrality)
+	}
+	return varTypes, nil
+}
+
+// SetColNames sets the names of all columns in one call. The number of
+// names must equal NumCol. If unique is true, duplicate names are
+// rejected, since some file formats require names to be unique.
+func (s *Solver) SetColNames(names []string, unique bool) error {
+	return s.setNames("SetColNames", true, s.NumCol(), names, unique)
+}
+
+// SetRowNames sets the names of all rows in one call. The number of
+// names must equal NumRow. If unique is true, duplicate names are
+// rejected, since some file formats require names to be unique.
+func (s *Solver) SetRowNames(names []string, unique bool) error {
+	return s.setNames("SetRowNames", false, s.NumRow(), names, unique)
+}
+
+func (s *Solver) setNames(op string, isCol bool, n int, names []string, unique bool) error {
+	if len(names) != n {
+		return newErrorMsg(op, fmt.Sprintf("got %d names, expected %d", len(names), n))
+	}
+	if unique {
+		seen := make(map[string]int, len(names))
+		for i, name := range names {
+			if j, ok := seen[name]; ok {
+				return newErrorMsg(op, fmt.Sprintf("duplicate name %q at indices %d and %d", name, j, i))
+			}
+			seen[name] = i
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+
+	cNames := make([]*C.char, n)
+	for i, name := range names {
+		cNames[i] = C.CString(name)
+	}
+	defer func() {
+		for _, cName := range cNames {
+			C.free(unsafe.Pointer(cName))
+		}
+	}()
+
+	var cIsCol C.int
+	if isCol {
+		cIsCol = 1
+	}
+	status := Status(C.gohighs_passNames(s.ptr, cIsCol, C.HighsInt(n), &cNames[0]))
+	return newError(op, status)
+}
+
+// ColName returns the name of the given column, such as one parsed by
+// ReadModel or set with SetColNames. It fails if the model has no names.
+func (s *Solver) ColName(col int) (string, error) {
+	return s.name("ColName", true, col, make([]C.char, C.kHighsMaximumStringLength))
+}
+
+// RowName returns the name of the given row.
+func (s *Solver) RowName(row int) (string, error) {
+	return s.name("RowName", false, row, make([]C.char, C.kHighsMaximumStringLength))
+}
+
+// ColNames returns the names of all columns, in column order, or an error
+// if the model has no names.
+func (s *Solver) ColNames() ([]string, error) {
+	return s.names("ColNames", true, s.NumCol())
+}
+
+// RowNames returns the names of all rows, in row order.
+func (s *Solver) RowNames() ([]string, error) {
+	return s.names("RowNames", false, s.NumRow())
+}
+
+func (s *Solver) names(op string, isCol bool, n int) ([]string, error) {
+	buf := make([]C.char, C.kHighsMaximumStringLength)
+	names := make([]string, n)
+	for i := range names {
+		name, err := s.name(op, isCol, i, buf)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+// name reads one column (isCol) or row name into buf, which must have
+// length kHighsMaximumStringLength, and copies it out.
+func (s *Solver) name(op string, isCol bool, i int, buf []C.char) (string, error) {
+	kind, n := "row", s.NumRow()
+	if isCol {
+		kind, n = "column", s.NumCol()
+	}
+	if i < 0 || i >= n {
+		return "", newErrorMsg(op, fmt.Sprintf("%s %d out of range [0, %d)", kind, i, n))
+	}
+	buf[0] = 0
+	var status Status
+	if isCol {
+		status = Status(C.Highs_getColName(s.ptr, C.HighsInt(i), &buf[0]))
+	} else {
+		status = Status(C.Highs_getRowName(s.ptr, C.HighsInt(i), &buf[0]))
+	}
+	if status == StatusError {
+		// HiGHS fails for models without names
+		return "", newErrorMsg(op, fmt.Sprintf("%s %d has no name", kind, i))
+	}
+	// HiGHS null-terminates the name, but guard against overflow
+	buf[len(buf)-1] = 0
+	return C.GoString(&buf[0]), nil
+}
+
+// PassModel passes a complete model to the solver in one call.
 // This is more efficient than adding variables and constraints one at a time.
+// The constraint matrix is given in compressed sparse row format.
 func (s *Solver) PassModel(
 	numCol, numRow int,
 	colCost, colLower, colUpper []float64,
@@ -660,6 +1257,26 @@ func (s *Solver) PassModel(
 	integrality []VariableType,
 	maximize bool,
 	offset float64,
+) error {
+	return s.passModel(MatrixFormatRowwise,
+		numCol, numRow,
+		colCost, colLower, colUpper,
+		rowLower, rowUpper,
+		aStart, aIndex, aValue,
+		integrality, maximize, offset)
+}
+
+// passModel is PassModel with the constraint matrix in the given format.
+func (s *Solver) passModel(
+	format MatrixFormat,
+	numCol, numRow int,
+	colCost, colLower, colUpper []float64,
+	rowLower, rowUpper []float64,
+	aStart, aIndex []int,
+	aValue []float64,
+	integrality []VariableType,
+	maximize bool,
+	offset float64,
 ) error {
 	// Convert to C types
 	sense := C.kHighsObjSenseMinimize
@@ -719,10 +1336,11 @@ func (s *Solver) PassModel(
 		pAValue = (*C.double)(&aValue[0])
 	}
 
+	s.hessianConvexity = [2]convexity{}
 	status := Status(C.Highs_passModel(s.ptr,
 		C.HighsInt(numCol), C.HighsInt(numRow),
 		C.HighsInt(len(aValue)), 0, // num_nz, q_num_nz
-		C.kHighsMatrixFormatRowwise, C.kHighsHessianFormatTriangular,
+		format.toC(), C.kHighsHessianFormatTriangular,
 		C.HighsInt(sense), C.double(offset),
 		pColCost, pColLower, pColUpper,
 		pRowLower, pRowUpper,
@@ -734,6 +1352,8 @@ func (s *Solver) PassModel(
 
 // PassHessian sets the Hessian matrix for quadratic programming.
 // The Hessian must be provided in upper-triangular compressed sparse column format.
+// Its convexity is checked here, once, so that Run can warn about a
+// non-convex QP without inspecting the model.
 func (s *Solver) PassHessian(dim int, start, index []int, value []float64) error {
 	if len(index) != len(value) {
 		return newErrorMsg("PassHessian", "index and value must have same length")
@@ -764,72 +1384,420 @@ func (s *Solver) PassHessian(dim int, start, index []int, value []float64) error
 		C.HighsInt(dim), C.HighsInt(len(value)),
 		C.kHighsHessianFormatTriangular,
 		pStart, pIndex, pValue))
-	return newError("PassHessian", status)
+	if err := newError("PassHessian", status); err != nil {
+		s.hessianConvexity = [2]convexity{}
+		return err
+	}
+	hessian := make([]Nonzero, 0, len(value))
+	for col := 0; col < dim && col < len(start); col++ {
+		end := len(index)
+		if col+1 < len(start) {
+			end = start[col+1]
+		}
+		for k := start[col]; k < end; k++ {
+			hessian = append(hessian, Nonzero{Row: index[k], Col: col, Val: value[k]})
+		}
+	}
+	s.hessianConvexity = [2]convexity{
+		hessianConvexity(hessian, false),
+		hessianConvexity(hessian, true),
+	}
+	return nil
+}
+
+// setSolution sets colValue as the starting solution of the next Run,
+// used as a MIP start.
+func (s *Solver) setSolution(colValue []float64) error {
+	numCol := s.NumCol()
+	if len(colValue) != numCol {
+		return newErrorMsg("SetSolution", fmt.Sprintf("solution has %d values, expected %d columns", len(colValue), numCol))
+	}
+	if numCol == 0 {
+		return nil
+	}
+	status := Status(C.Highs_setSolution(s.ptr, (*C.double)(&colValue[0]), nil, nil, nil))
+	return newError("SetSolution", status)
+}
+
+// SetBasis sets the basis the next Run starts from, such as the ColBasis
+// and RowBasis of a solution to a closely related model, which can save
+// most simplex iterations. colBasis and rowBasis must have one status per
+// column and row of the current model, and exactly NumRow of the
+// statuses must be BasisStatusBasic.
+func (s *Solver) SetBasis(colBasis, rowBasis []BasisStatus) error {
+	numCol, numRow := s.NumCol(), s.NumRow()
+	if len(colBasis) != numCol {
+		return newErrorMsg("SetBasis", fmt.Sprintf("colBasis has length %d, expected %d columns", len(colBasis), numCol))
+	}
+	if len(rowBasis) != numRow {
+		return newErrorMsg("SetBasis", fmt.Sprintf("rowBasis has length %d, expected %d rows", len(rowBasis), numRow))
+	}
+	numBasic := 0
+	for _, basis := range [][]BasisStatus{colBasis, rowBasis} {
+		for _, b := range basis {
+			if b < BasisStatusLower || b > BasisStatusNonbasic {
+				return newErrorMsg("SetBasis", fmt.Sprintf("invalid basis status %d", b))
+			}
+			if b == BasisStatusBasic {
+				numBasic++
+			}
+		}
+	}
+	if numBasic != numRow {
+		return newErrorMsg("SetBasis", fmt.Sprintf("basis has %d basic variables, expected %d (one per row)", numBasic, numRow))
+	}
+
+	cColBasis := make([]C.HighsInt, numCol)
+	for i, b := range colBasis {
+		cColBasis[i] = b.toC()
+	}
+	cRowBasis := make([]C.HighsInt, numRow)
+	for i, b := range rowBasis {
+		cRowBasis[i] = b.toC()
+	}
+	var pColBasis, pRowBasis *C.HighsInt
+	if numCol > 0 {
+		pColBasis = &cColBasis[0]
+	}
+	if numRow > 0 {
+		pRowBasis = &cRowBasis[0]
+	}
+	status := Status(C.Highs_setBasis(s.ptr, pColBasis, pRowBasis))
+	return newError("SetBasis", status)
+}
+
+// GetModelStatus returns the status of the model after the last Run.
+func (s *Solver) GetModelStatus() ModelStatus {
+	return modelStatusFromC(C.Highs_getModelStatus(s.ptr))
+}
+
+// GetScaledModelStatus returns the status of the scaled model HiGHS
+// solved internally in the last Run. It can differ from GetModelStatus on
+// numerically hard problems, when a solution optimal for the scaled model
+// violates tolerances once unscaled.
+func (s *Solver) GetScaledModelStatus() ModelStatus {
+	return modelStatusFromC(C.Highs_getScaledModelStatus(s.ptr))
+}
+
+// Presolve runs HiGHS presolve on the loaded model without solving it,
+// for example to see how far it reduces the model before committing to
+// a full solve. It returns the model status afterwards, which is
+// ModelStatusNotSet unless presolve alone settled the model, for example
+// by detecting infeasibility. The reduced model's dimensions are given by
+// PresolvedNumCol and PresolvedNumRow, and WritePresolvedModel writes it.
+func (s *Solver) Presolve() (ModelStatus, error) {
+	status := Status(C.Highs_presolve(s.ptr))
+	if err := newError("Presolve", status); err != nil {
+		return ModelStatusNotSet, err
+	}
+	return s.GetModelStatus(), nil
+}
+
+// PresolvedNumCol returns the number of columns of the model reduced by
+// the last Presolve.
+func (s *Solver) PresolvedNumCol() int {
+	return int(C.Highs_getPresolvedNumCol(s.ptr))
+}
+
+// PresolvedNumRow returns the number of rows of the model reduced by the
+// last Presolve.
+func (s *Solver) PresolvedNumRow() int {
+	return int(C.Highs_getPresolvedNumRow(s.ptr))
+}
+
+// Postsolve maps colValue, a primal solution of the model reduced by the
+// last Presolve found by other means, back to the original model and
+// returns the resulting solution. Its length must be PresolvedNumCol.
+// No row values are passed: HiGHS recomputes them from the columns.
+// HiGHS does not certify the postsolved solution, so its status is
+// usually ModelStatusUnknown even when the reduced solution was optimal.
+// Use PostsolveDual to map dual values as well.
+func (s *Solver) Postsolve(colValue []float64) (*Solution, error) {
+	return s.postsolve("Postsolve", colValue, nil, nil)
+}
+
+// PostsolveDual is Postsolve with the column and row duals of the reduced
+// model, of lengths PresolvedNumCol and PresolvedNumRow, so that the
+// returned solution carries duals for the original model too.
+func (s *Solver) PostsolveDual(colValue, colDual, rowDual []float64) (*Solution, error) {
+	if len(colDual) != s.PresolvedNumCol() || len(rowDual) != s.PresolvedNumRow() {
+		return nil, newErrorMsg("PostsolveDual", fmt.Sprintf("duals must have lengths %d and %d, got %d and %d",
+			s.PresolvedNumCol(), s.PresolvedNumRow(), len(colDual), len(rowDual)))
+	}
+	return s.postsolve("PostsolveDual", colValue, colDual, rowDual)
+}
+
+func (s *Solver) postsolve(op string, colValue, colDual, rowDual []float64) (*Solution, error) {
+	numCol := s.PresolvedNumCol()
+	if len(colValue) != numCol {
+		return nil, newErrorMsg(op, fmt.Sprintf("colValue must have length %d, got %d", numCol, len(colValue)))
+	}
+	var pColValue, pColDual, pRowDual *C.double
+	if numCol > 0 {
+		pColValue = (*C.double)(&colValue[0])
+	}
+	if colDual != nil && rowDual != nil {
+		// HiGHS reads the duals only when both pointers are set
+		var colDummy, rowDummy C.double
+		pColDual, pRowDual = &colDummy, &rowDummy
+		if len(colDual) > 0 {
+			pColDual = (*C.double)(&colDual[0])
+		}
+		if len(rowDual) > 0 {
+			pRowDual = (*C.double)(&rowDual[0])
+		}
+	}
+	status := Status(C.Highs_postsolve(s.ptr, pColValue, pColDual, pRowDual))
+	if err := newError(op, status); err != nil {
+		return nil, err
+	}
+
+	numCol, numRow := s.NumCol(), s.NumRow()
+	sol := &Solution{
+		Status:    s.GetModelStatus(),
+		ColValues: make([]float64, numCol),
+		ColDuals:  make([]float64, numCol),
+		RowValues: make([]float64, numRow),
+		RowDuals:  make([]float64, numRow),
+		Objective: float64(C.Highs_getObjectiveValue(s.ptr)),
+		Populated: true,
+		Info:      SolveInfo{Version: Version()},
+	}
+	var pRowValue *C.double
+	pColValue, pColDual, pRowDual = nil, nil, nil
+	if numCol > 0 {
+		pColValue = (*C.double)(&sol.ColValues[0])
+		pColDual = (*C.double)(&sol.ColDuals[0])
+	}
+	if numRow > 0 {
+		pRowValue = (*C.double)(&sol.RowValues[0])
+		pRowDual = (*C.double)(&sol.RowDuals[0])
+	}
+	status = Status(C.Highs_getSolution(s.ptr, pColValue, pColDual, pRowValue, pRowDual))
+	if err := newError(op, status); err != nil {
+		return nil, err
+	}
+	return sol, nil
 }
 
 // Run solves the model and returns the solution.
 func (s *Solver) Run() (*Solution, error) {
-	status := Status(C.Highs_run(s.ptr))
+	sol := &Solution{}
+	if err := s.RunInto(sol); err != nil {
+		return nil, err
+	}
+	return sol, nil
+}
+
+// RunInto solves the model and writes the solution into sol, reusing its
+// slices when they have enough capacity. Repeated calls on a model with
+// stable dimensions therefore do not allocate, which suits hot re-solve
+// loops. On error sol is left unchanged.
+func (s *Solver) RunInto(sol *Solution) error {
+	if s.solutionFilter != nil {
+		return s.runFiltered(sol)
+	}
+	return s.runInto(sol)
+}
+
+// nonConvexQPWarning is added to Solution.Warnings when the Hessian of a
+// QP is not convex for the objective sense.
+const nonConvexQPWarning = "QP Hessian is not convex: the solution may be only a local optimum"
+
+// uncheckedQPWarning is added to Solution.Warnings when the convexity of
+// a QP Hessian is unknown, because it was not passed through PassHessian
+// or is too large to check.
+const uncheckedQPWarning = "QP Hessian convexity was not checked: the solution may be only a local optimum"
+
+// runInto performs a single solve for RunInto.
+func (s *Solver) runInto(sol *Solution) error {
+	status, err := s.runLogged()
+	if err != nil {
+		return err
+	}
 	if status == StatusError {
-		return nil, newError("Run", status)
+		return newError("Run", status)
 	}
 
 	// Get model status
-	modelStatus := modelStatusFromC(C.Highs_getModelStatus(s.ptr))
+	sol.Status = s.GetModelStatus()
 
 	// Get dimensions
 	numCol := int(C.Highs_getNumCol(s.ptr))
 	numRow := int(C.Highs_getNumRow(s.ptr))
 
-	// Allocate solution arrays
-	colValue := make([]float64, numCol)
-	colDual := make([]float64, numCol)
-	rowValue := make([]float64, numRow)
-	rowDual := make([]float64, numRow)
+	// Size solution arrays
+	sol.ColValues = growFloat64s(sol.ColValues, numCol)
+	sol.ColDuals = growFloat64s(sol.ColDuals, numCol)
+	sol.RowValues = growFloat64s(sol.RowValues, numRow)
+	sol.RowDuals = growFloat64s(sol.RowDuals, numRow)
 
 	var pColValue, pColDual, pRowValue, pRowDual *C.double
 	if numCol > 0 {
-		pColValue = (*C.double)(&colValue[0])
-		pColDual = (*C.double)(&colDual[0])
+		pColValue = (*C.double)(&sol.ColValues[0])
+		pColDual = (*C.double)(&sol.ColDuals[0])
 	}
 	if numRow > 0 {
-		pRowValue = (*C.double)(&rowValue[0])
-		pRowDual = (*C.double)(&rowDual[0])
+		pRowValue = (*C.double)(&sol.RowValues[0])
+		pRowDual = (*C.double)(&sol.RowDuals[0])
 	}
 
 	// Get solution
 	C.Highs_getSolution(s.ptr, pColValue, pColDual, pRowValue, pRowDual)
+	primalStatus, ok := s.scratchIntInfo("primal_solution_status")
+	sol.Populated = ok && primalStatus != 0 // kSolutionStatusNone
 
 	// Get objective value
-	objective := float64(C.Highs_getObjectiveValue(s.ptr))
+	sol.Objective = float64(C.Highs_getObjectiveValue(s.ptr))
+	if s.trackObjective {
+		s.objectiveHistory = append(s.objectiveHistory, sol.Objective)
+	}
+	sol.Info = SolveInfo{Version: Version()}
+	if iterations, ok := s.scratchIntInfo("simplex_iteration_count"); ok && iterations > 0 {
+		sol.Info.SimplexIterations = int(iterations)
+	}
+	sol.Warnings = append(sol.Warnings[:0], s.log.warnings...)
+
+	// HiGHS rejects Hessians with wrong-signed diagonal entries but solves
+	// other non-convex QPs to a stationary point and still reports it as
+	// optimal, so flag the result as possibly only locally optimal
+	if sol.Populated && C.Highs_getHessianNumNz(s.ptr) > 0 {
+		sense := 0
+		if C.Highs_getObjectiveSense(s.ptr, &s.infoScratch) == C.kHighsStatusOk &&
+			s.infoScratch == C.kHighsObjSenseMaximize {
+			sense = 1
+		}
+		switch s.hessianConvexity[sense] {
+		case convexityNonConvex:
+			sol.Warnings = append(sol.Warnings, nonConvexQPWarning)
+		case convexityUnchecked:
+			sol.Warnings = append(sol.Warnings, uncheckedQPWarning)
+		}
+	}
 
-	sol := &Solution{
-		Status:    modelStatus,
-		ColValues: colValue,
-		ColDuals:  colDual,
-		RowValues: rowValue,
-		RowDuals:  rowDual,
-		Objective: objective,
-	}
-
-	// Try to get basis info
-	if numCol > 0 && numRow > 0 {
-		colBasis := make([]C.HighsInt, numCol)
-		rowBasis := make([]C.HighsInt, numRow)
-		basisStatus := C.Highs_getBasis(s.ptr, &colBasis[0], &rowBasis[0])
+	// Get basis info whenever HiGHS holds a valid basis, including after
+	// a time or iteration limit, so the solve can be resumed later
+	sol.ColBasis = sol.ColBasis[:0]
+	sol.RowBasis = sol.RowBasis[:0]
+	validity, ok := s.scratchIntInfo("basis_validity")
+	if ok && validity == C.kHighsBasisValidityValid && numCol+numRow > 0 {
+		s.colBasis = growHighsInts(s.colBasis, numCol)
+		s.rowBasis = growHighsInts(s.rowBasis, numRow)
+		var pColBasis, pRowBasis *C.HighsInt
+		if numCol > 0 {
+			pColBasis = &s.colBasis[0]
+		}
+		if numRow > 0 {
+			pRowBasis = &s.rowBasis[0]
+		}
+		basisStatus := C.Highs_getBasis(s.ptr, pColBasis, pRowBasis)
 		if Status(basisStatus) == StatusOK {
-			sol.ColBasis = make([]BasisStatus, numCol)
-			sol.RowBasis = make([]BasisStatus, numRow)
-			for i, b := range colBasis {
+			sol.ColBasis = growBasisStatuses(sol.ColBasis, numCol)
+			sol.RowBasis = growBasisStatuses(sol.RowBasis, numRow)
+			for i, b := range s.colBasis {
 				sol.ColBasis[i] = basisStatusFromC(b)
 			}
-			for i, b := range rowBasis {
+			for i, b := range s.rowBasis {
 				sol.RowBasis[i] = basisStatusFromC(b)
 			}
 		}
 	}
 
-	return sol, nil
+	return nil
+}
+
+// SetObjectiveTracking enables or disables recording the objective value
+// after each Run, so the effect of successive model edits can be
+// inspected with ObjectiveHistory. Tracking is off by default because it
+// allocates, which RunInto otherwise avoids.
+func (s *Solver) SetObjectiveTracking(enabled bool) {
+	s.trackObjective = enabled
+}
+
+// ObjectiveHistory returns the objective values recorded by successive
+// runs while objective tracking was enabled, oldest first.
+func (s *Solver) ObjectiveHistory() []float64 {
+	return append([]float64(nil), s.objectiveHistory...)
+}
+
+// scratchIntInfo is GetIntInfo reading into a solver-owned scratch
+// variable, so RunInto does not allocate.
+func (s *Solver) scratchIntInfo(name string) (C.HighsInt, bool) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	status := Status(C.Highs_getIntInfoValue(s.ptr, cName, &s.infoScratch))
+	return s.infoScratch, status == StatusOK
+}
+
+func growFloat64s(v []float64, n int) []float64 {
+	if cap(v) >= n {
+		return v[:n]
+	}
+	return make([]float64, n)
+}
+
+func growHighsInts(v []C.HighsInt, n int) []C.HighsInt {
+	if cap(v) >= n {
+		return v[:n]
+	}
+	return make([]C.HighsInt, n)
+}
+
+func growBasisStatuses(v []BasisStatus, n int) []BasisStatus {
+	if cap(v) >= n {
+		return v[:n]
+	}
+	return make([]BasisStatus, n)
+}
+
+// VerifyIntegrality checks that every integer and semi-integer column of
+// the current solution is integral to within tol. It returns whether all
+// columns pass, along with the indices of the columns that violate
+// integrality by more than tol.
+func (s *Solver) VerifyIntegrality(tol float64) (bool, []int, error) {
+	varTypes, err := s.Integralities()
+	if err != nil {
+		return false, nil, err
+	}
+	if len(varTypes) == 0 {
+		return true, nil, nil
+	}
+
+	colValue := make([]float64, len(varTypes))
+	status := Status(C.Highs_getSolution(s.ptr, (*C.double)(&colValue[0]), nil, nil, nil))
+	if err := newError("VerifyIntegrality", status); err != nil {
+		return false, nil, err
+	}
+
+	var violations []int
+	for i, vt := range varTypes {
+		if vt != Integer && vt != SemiInteger {
+			continue
+		}
+		if math.Abs(colValue[i]-math.Round(colValue[i])) > tol {
+			violations = append(violations, i)
+		}
+	}
+	return len(violations) == 0, violations, nil
+}
+
+// RowActivity returns the activity (A·x) of a single row in the current
+// solution. The HiGHS C API has no single-row accessor, so the row
+// activities are still copied internally, but the column values, duals,
+// and basis fetched by Run are skipped.
+func (s *Solver) RowActivity(row int) (float64, error) {
+	numRow := s.NumRow()
+	if row < 0 || row >= numRow {
+		return 0, newErrorMsg("RowActivity", fmt.Sprintf("row index %d out of range [0, %d)", row, numRow))
+	}
+
+	rowValue := make([]float64, numRow)
+	status := Status(C.Highs_getSolution(s.ptr, nil, nil, (*C.double)(&rowValue[0]), nil))
+	if err := newError("RowActivity", status); err != nil {
+		return 0, err
+	}
+	return rowValue[row], nil
 }
 
 // GetIntInfo returns an integer info value.
@@ -871,11 +1839,156 @@ func (s *Solver) GetFloatInfo(name string) (float64, error) {
 	return float64(val), nil
 }
 
+// Ranging returns cost and bound ranging for the optimal basis found by
+// the last Run. It requires an LP solved to optimality; MIPs are rejected
+// since their optimum has no basis to range.
+func (s *Solver) Ranging() (*Ranging, error) {
+	varTypes, err := s.Integralities()
+	if err != nil {
+		return nil, err
+	}
+	for col, t := range varTypes {
+		if t != Continuous {
+			return nil, newErrorMsg("Ranging", fmt.Sprintf("column %d is %s: ranging requires an LP", col, t))
+		}
+	}
+	if status := s.GetModelStatus(); status != ModelStatusOptimal {
+		return nil, newErrorMsg("Ranging", fmt.Sprintf("model status is %s, not optimal", status))
+	}
+
+	numCol, numRow := s.NumCol(), s.NumRow()
+	type record struct {
+		value, objective []float64
+		inVar, outVar    []C.HighsInt
+	}
+	newRecord := func(n int) *record {
+		return &record{
+			value:     make([]float64, n+1),
+			objective: make([]float64, n+1),
+			inVar:     make([]C.HighsInt, n+1),
+			outVar:    make([]C.HighsInt, n+1),
+		}
+	}
+	// Records are allocated one longer so &v[0] is valid for empty models
+	records := []*record{
+		newRecord(numCol), newRecord(numCol),
+		newRecord(numCol), newRecord(numCol),
+		newRecord(numRow), newRecord(numRow),
+	}
+	r := records
+	status := Status(C.Highs_getRanging(s.ptr,
+		(*C.double)(&r[0].value[0]), (*C.double)(&r[0].objective[0]), &r[0].inVar[0], &r[0].outVar[0],
+		(*C.double)(&r[1].value[0]), (*C.double)(&r[1].objective[0]), &r[1].inVar[0], &r[1].outVar[0],
+		(*C.double)(&r[2].value[0]), (*C.double)(&r[2].objective[0]), &r[2].inVar[0], &r[2].outVar[0],
+		(*C.double)(&r[3].value[0]), (*C.double)(&r[3].objective[0]), &r[3].inVar[0], &r[3].outVar[0],
+		(*C.double)(&r[4].value[0]), (*C.double)(&r[4].objective[0]), &r[4].inVar[0], &r[4].outVar[0],
+		(*C.double)(&r[5].value[0]), (*C.double)(&r[5].objective[0]), &r[5].inVar[0], &r[5].outVar[0]))
+	if err := newError("Ranging", status); err != nil {
+		return nil, err
+	}
+
+	convert := func(rec *record) RangingRecord {
+		n := len(rec.value) - 1
+		out := RangingRecord{
+			Value:     rec.value[:n],
+			Objective: rec.objective[:n],
+			InVar:     make([]int, n),
+			OutVar:    make([]int, n),
+		}
+		for i := 0; i < n; i++ {
+			out.InVar[i] = int(rec.inVar[i])
+			out.OutVar[i] = int(rec.outVar[i])
+		}
+		return out
+	}
+	return &Ranging{
+		ColCostUp:    convert(r[0]),
+		ColCostDown:  convert(r[1]),
+		ColBoundUp:   convert(r[2]),
+		ColBoundDown: convert(r[3]),
+		RowBoundUp:   convert(r[4]),
+		RowBoundDown: convert(r[5]),
+	}, nil
+}
+
+// PrimalRay returns a primal ray, a direction along which the objective
+// improves without bound, certifying that the model is unbounded. The
+// bool reports whether a ray exists; HiGHS may solve an LP to find it.
+func (s *Solver) PrimalRay() ([]float64, bool, error) {
+	ray := make([]float64, s.NumCol())
+	var pRay *C.double
+	if len(ray) > 0 {
+		pRay = (*C.double)(&ray[0])
+	}
+	var hasRay C.HighsInt
+	status := Status(C.Highs_getPrimalRay(s.ptr, &hasRay, pRay))
+	if err := newError("PrimalRay", status); err != nil {
+		return nil, false, err
+	}
+	if hasRay == 0 {
+		return nil, false, nil
+	}
+	return ray, true, nil
+}
+
+// DualRay returns a dual ray, one multiplier per row, certifying that the
+// model is infeasible: the combination of constraints it weights yields a
+// contradiction. The bool reports whether a ray exists; HiGHS may solve an
+// LP to find it.
+func (s *Solver) DualRay() ([]float64, bool, error) {
+	ray := make([]float64, s.NumRow())
+	var pRay *C.double
+	if len(ray) > 0 {
+		pRay = (*C.double)(&ray[0])
+	}
+	var hasRay C.HighsInt
+	status := Status(C.Highs_getDualRay(s.ptr, &hasRay, pRay))
+	if err := newError("DualRay", status); err != nil {
+		return nil, false, err
+	}
+	if hasRay == 0 {
+		return nil, false, nil
+	}
+	return ray, true, nil
+}
+
+// GetResiduals returns the infeasibility and optimality measures HiGHS
+// computed for the last solution. Measures that do not apply, such as
+// dual infeasibilities without a dual solution, are zero.
+func (s *Solver) GetResiduals() (*Residuals, error) {
+	r := &Residuals{}
+	fields := []struct {
+		name string
+		dst  *float64
+	}{
+		{"max_primal_infeasibility", &r.MaxPrimalInfeasibility},
+		{"sum_primal_infeasibilities", &r.SumPrimalInfeasibilities},
+		{"max_dual_infeasibility", &r.MaxDualInfeasibility},
+		{"sum_dual_infeasibilities", &r.SumDualInfeasibilities},
+		{"max_relative_primal_infeasibility", &r.MaxRelativePrimalInfeasibility},
+		{"max_relative_dual_infeasibility", &r.MaxRelativeDualInfeasibility},
+		{"max_complementarity_violation", &r.MaxComplementarityViolation},
+		{"primal_dual_objective_error", &r.PrimalDualObjectiveError},
+	}
+	for _, f := range fields {
+		v, err := s.GetFloatInfo(f.name)
+		if err != nil {
+			return nil, newErrorMsg("GetResiduals", fmt.Sprintf("info %q unavailable", f.name))
+		}
+		// HiGHS reports unset measures as infinite or negative
+		if !math.IsInf(v, 0) && v > 0 {
+			*f.dst = v
+		}
+	}
+	return r, nil
+}
+
 // ReadModel reads a model from a file (LP, MPS, or other supported format).
 func (s *Solver) ReadModel(filename string) error {
 	cFilename := C.CString(filename)
 	defer C.free(unsafe.Pointer(cFilename))
 
+	s.hessianConvexity = [2]convexity{}
 	status := Status(C.Highs_readModel(s.ptr, cFilename))
 	return newError("ReadModel", status)
 }
@@ -889,6 +2002,258 @@ func (s *Solver) WriteModel(filename string) error {
 	return newError("WriteModel", status)
 }
 
+// WritePresolvedModel writes the model reduced by the last Presolve to a
+// file, in a format chosen by its extension as for WriteModel.
+func (s *Solver) WritePresolvedModel(filename string) error {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	status := Status(C.Highs_writePresolvedModel(s.ptr, cFilename))
+	return newError("WritePresolvedModel", status)
+}
+
+// WriteOptions writes all options and their current values to a file in
+// HiGHS options format, which ReadOptions reads back.
+func (s *Solver) WriteOptions(filename string) error {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	status := Status(C.Highs_writeOptions(s.ptr, cFilename))
+	return newError("WriteOptions", status)
+}
+
+// WriteModifiedOptions is WriteOptions restricted to the options whose
+// values differ from their defaults.
+func (s *Solver) WriteModifiedOptions(filename string) error {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	status := Status(C.Highs_writeOptionsDeviations(s.ptr, cFilename))
+	return newError("WriteModifiedOptions", status)
+}
+
+// ReadOptions sets the options listed in a file in HiGHS options format,
+// such as one written by WriteOptions. Options not in the file keep their
+// values.
+func (s *Solver) ReadOptions(filename string) error {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	status := Status(C.Highs_readOptions(s.ptr, cFilename))
+	return newError("ReadOptions", status)
+}
+
+// GetColsByRange returns everything about columns from through to
+// (inclusive, as in the HiGHS API): costs, bounds, integrality, and
+// their constraint matrix entries.
+func (s *Solver) GetColsByRange(from, to int) (*Columns, error) {
+	numCol := s.NumCol()
+	if from < 0 || to >= numCol || from > to {
+		return nil, newErrorMsg("GetColsByRange", fmt.Sprintf("invalid range [%d, %d] for %d columns", from, to, numCol))
+	}
+	n := to - from + 1
+
+	// First pass sizes the matrix, second pass fills it
+	var gotCol, numNz C.HighsInt
+	status := Status(C.Highs_getColsByRange(s.ptr, C.HighsInt(from), C.HighsInt(to),
+		&gotCol, nil, nil, nil, &numNz, nil, nil, nil))
+	if err := newError("GetColsByRange", status); err != nil {
+		return nil, err
+	}
+
+	cols := &Columns{
+		Cost:        make([]float64, n),
+		Lower:       make([]float64, n),
+		Upper:       make([]float64, n),
+		Integrality: make([]VariableType, n),
+		Start:       make([]int, n),
+		Index:       make([]int, numNz),
+		Value:       make([]float64, numNz),
+	}
+	cStart := make([]C.HighsInt, n)
+	cIndex := make([]C.HighsInt, numNz)
+	var pIndex *C.HighsInt
+	var pValue *C.double
+	if numNz > 0 {
+		pIndex = &cIndex[0]
+		pValue = (*C.double)(&cols.Value[0])
+	}
+	status = Status(C.Highs_getColsByRange(s.ptr, C.HighsInt(from), C.HighsInt(to),
+		&gotCol,
+		(*C.double)(&cols.Cost[0]), (*C.double)(&cols.Lower[0]), (*C.double)(&cols.Upper[0]),
+		&numNz, &cStart[0], pIndex, pValue))
+	if err := newError("GetColsByRange", status); err != nil {
+		return nil, err
+	}
+	for i, v := range cStart {
+		cols.Start[i] = int(v)
+	}
+	for i, v := range cIndex {
+		cols.Index[i] = int(v)
+	}
+
+	for i := range cols.Integrality {
+		var integrality C.HighsInt
+		status := Status(C.Highs_getColIntegrality(s.ptr, C.HighsInt(from+i), &integrality))
+		if err := newError("GetColsByRange", status); err != nil {
+			return nil, err
+		}
+		cols.Integrality[i] = variableTypeFromC(integrality)
+	}
+	return cols, nil
+}
+
+// GetCol returns the cost, bounds, and constraint matrix entries of a
+// single column, with the entries as row indices and values.
+func (s *Solver) GetCol(col int) (cost, lower, upper float64, rows []int, values []float64, err error) {
+	if numCol := s.NumCol(); col < 0 || col >= numCol {
+		return 0, 0, 0, nil, nil, newErrorMsg("GetCol", fmt.Sprintf("column %d out of range [0, %d)", col, numCol))
+	}
+	cols, err := s.GetColsByRange(col, col)
+	if err != nil {
+		return 0, 0, 0, nil, nil, err
+	}
+	return cols.Cost[0], cols.Lower[0], cols.Upper[0], cols.Index, cols.Value, nil
+}
+
+// GetRow returns the bounds and constraint matrix entries of a single
+// row, with the entries as column indices and values.
+func (s *Solver) GetRow(row int) (lower, upper float64, cols []int, values []float64, err error) {
+	if numRow := s.NumRow(); row < 0 || row >= numRow {
+		return 0, 0, nil, nil, newErrorMsg("GetRow", fmt.Sprintf("row %d out of range [0, %d)", row, numRow))
+	}
+
+	// First pass sizes the entries, second pass fills them
+	var gotRow, numNz C.HighsInt
+	status := Status(C.Highs_getRowsByRange(s.ptr, C.HighsInt(row), C.HighsInt(row),
+		&gotRow, nil, nil, &numNz, nil, nil, nil))
+	if err := newError("GetRow", status); err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	var cLower, cUpper C.double
+	var start C.HighsInt
+	cIndex := make([]C.HighsInt, numNz)
+	values = make([]float64, numNz)
+	var pIndex *C.HighsInt
+	var pValue *C.double
+	if numNz > 0 {
+		pIndex = &cIndex[0]
+		pValue = (*C.double)(&values[0])
+	}
+	status = Status(C.Highs_getRowsByRange(s.ptr, C.HighsInt(row), C.HighsInt(row),
+		&gotRow, &cLower, &cUpper, &numNz, &start, pIndex, pValue))
+	if err := newError("GetRow", status); err != nil {
+		return 0, 0, nil, nil, err
+	}
+	cols = make([]int, numNz)
+	for i, v := range cIndex {
+		cols[i] = int(v)
+	}
+	return float64(cLower), float64(cUpper), cols, values, nil
+}
+
+// GetModel returns the model currently loaded in the solver, for example
+// after ReadModel. Bounds that HiGHS treats as infinite are returned as
+// ±math.Inf. VarTypes is only populated when some column is not continuous.
+func (s *Solver) GetModel() (*Model, error) {
+	numCol := s.NumCol()
+	numRow := s.NumRow()
+	numNz := s.NumNonzero()
+	hessianNumNz := int(C.Highs_getHessianNumNz(s.ptr))
+
+	colCost := make([]float64, numCol)
+	colLower := make([]float64, numCol)
+	colUpper := make([]float64, numCol)
+	rowLower := make([]float64, numRow)
+	rowUpper := make([]float64, numRow)
+	aStart := make([]C.HighsInt, numRow+1)
+	aIndex := make([]C.HighsInt, numNz)
+	aValue := make([]float64, numNz)
+	qStart := make([]C.HighsInt, numCol+1)
+	qIndex := make([]C.HighsInt, hessianNumNz)
+	qValue := make([]float64, hessianNumNz)
+	integrality := make([]C.HighsInt, numCol)
+
+	var pColCost, pColLower, pColUpper, pRowLower, pRowUpper *C.double
+	var pAIndex, pQIndex, pIntegrality *C.HighsInt
+	var pAValue, pQValue *C.double
+	if numCol > 0 {
+		pColCost = (*C.double)(&colCost[0])
+		pColLower = (*C.double)(&colLower[0])
+		pColUpper = (*C.double)(&colUpper[0])
+		pIntegrality = &integrality[0]
+	}
+	if numRow > 0 {
+		pRowLower = (*C.double)(&rowLower[0])
+		pRowUpper = (*C.double)(&rowUpper[0])
+	}
+	if numNz > 0 {
+		pAIndex = &aIndex[0]
+		pAValue = (*C.double)(&aValue[0])
+	}
+	if hessianNumNz > 0 {
+		pQIndex = &qIndex[0]
+		pQValue = (*C.double)(&qValue[0])
+	}
+
+	var cNumCol, cNumRow, cNumNz, cHessianNumNz, sense C.HighsInt
+	var offset C.double
+	status := Status(C.Highs_getModel(s.ptr,
+		C.kHighsMatrixFormatRowwise, C.kHighsHessianFormatTriangular,
+		&cNumCol, &cNumRow, &cNumNz, &cHessianNumNz,
+		&sense, &offset,
+		pColCost, pColLower, pColUpper,
+		pRowLower, pRowUpper,
+		&aStart[0], pAIndex, pAValue,
+		&qStart[0], pQIndex, pQValue,
+		pIntegrality))
+	if err := newError("GetModel", status); err != nil {
+		return nil, err
+	}
+
+	m := &Model{
+		Maximize: sense == C.kHighsObjSenseMaximize,
+		Offset:   float64(offset),
+		ColCosts: colCost,
+		ColLower: colLower,
+		ColUpper: colUpper,
+		RowLower: rowLower,
+		RowUpper: rowUpper,
+	}
+
+	// The constraint matrix is row-wise, so start[i] marks the first entry of row i.
+	aStart[numRow] = C.HighsInt(numNz)
+	for row := 0; row < numRow; row++ {
+		for k := aStart[row]; k < aStart[row+1]; k++ {
+			m.ConstMatrix = append(m.ConstMatrix, Nonzero{Row: row, Col: int(aIndex[k]), Val: aValue[k]})
+		}
+	}
+
+	// The Hessian is stored as the lower triangle by column, which is the
+	// transpose of the upper-triangular form used by Model.
+	if hessianNumNz > 0 {
+		qStart[numCol] = C.HighsInt(hessianNumNz)
+		for col := 0; col < numCol; col++ {
+			for k := qStart[col]; k < qStart[col+1]; k++ {
+				m.Hessian = append(m.Hessian, Nonzero{Row: col, Col: int(qIndex[k]), Val: qValue[k]})
+			}
+		}
+	}
+
+	for col, v := range integrality {
+		if variableTypeFromC(v) == Continuous {
+			continue
+		}
+		if m.VarTypes == nil {
+			m.VarTypes = make([]VariableType, numCol)
+		}
+		m.VarTypes[col] = variableTypeFromC(v)
+	}
+
+	return m, nil
+}
+
 // WriteSolution writes the solution to a file.
 func (s *Solver) WriteSolution(filename string, pretty bool) error {
 	cFilename := C.CString(filename)
@@ -903,3 +2268,88 @@ func (s *Solver) WriteSolution(filename string, pretty bool) error {
 	return newError("WriteSolution", Status(status))
 }
 
+// ----------------------------------------------------------------------------
+// Infeasibility analysis
+// ----------------------------------------------------------------------------
+
+// getIIS computes an IIS of the loaded model, returning its rows and the
+// columns with bounds in it. The iis_strategy option it sets is restored
+// before it returns.
+func (s *Solver) getIIS() (rows, cols []int, err error) {
+	strategy, err := s.GetIntOption("iis_strategy")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.SetIntOption("iis_strategy", int(C.kHighsIisStrategyFromLpRowPriority)); err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if restoreErr := s.SetIntOption("iis_strategy", strategy); err == nil && restoreErr != nil {
+			rows, cols, err = nil, nil, restoreErr
+		}
+	}()
+
+	// An IIS is no larger than the model. The per-column and per-row status
+	// arrays are not requested: HiGHS does not fill them for every strategy.
+	numCol, numRow := s.NumCol(), s.NumRow()
+	colIndex := make([]C.HighsInt, numCol+1)
+	colBound := make([]C.HighsInt, numCol+1)
+	rowIndex := make([]C.HighsInt, numRow+1)
+	rowBound := make([]C.HighsInt, numRow+1)
+	var iisNumCol, iisNumRow C.HighsInt
+	status := Status(C.Highs_getIis(s.ptr, &iisNumCol, &iisNumRow,
+		&colIndex[0], &rowIndex[0], &colBound[0], &rowBound[0], nil, nil))
+	if err := newError("DiagnoseInfeasibility", status); err != nil {
+		return nil, nil, err
+	}
+
+	for i := 0; i < int(iisNumRow); i++ {
+		rows = append(rows, int(rowIndex[i]))
+	}
+	for i := 0; i < int(iisNumCol); i++ {
+		if colBound[i] != C.kHighsIisBoundFree {
+			cols = append(cols, int(colIndex[i]))
+		}
+	}
+	sort.Ints(rows)
+	sort.Ints(cols)
+	return rows, cols, nil
+}
+
+// feasibilityRelaxation solves the feasibility relaxation of the loaded
+// model and returns the bounds its solution violates.
+func (s *Solver) feasibilityRelaxation() ([]BoundRelaxation, error) {
+	status := Status(C.Highs_feasibilityRelaxation(s.ptr, 1, 1, 1, nil, nil, nil))
+	if err := newError("DiagnoseInfeasibility", status); err != nil {
+		return nil, err
+	}
+
+	model, err := s.GetModel()
+	if err != nil {
+		return nil, err
+	}
+	numCol, numRow := len(model.ColLower), len(model.RowLower)
+	colValue := make([]float64, numCol+1)
+	rowValue := make([]float64, numRow+1)
+	status = Status(C.Highs_getSolution(s.ptr, (*C.double)(&colValue[0]), nil, (*C.double)(&rowValue[0]), nil))
+	if err := newError("DiagnoseInfeasibility", status); err != nil {
+		return nil, err
+	}
+
+	var relaxations []BoundRelaxation
+	add := func(row bool, index int, lower, upper, value float64) {
+		switch {
+		case value < lower-relaxationTol*math.Max(1, math.Abs(lower)):
+			relaxations = append(relaxations, BoundRelaxation{Row: row, Index: index, Lower: value, Upper: upper})
+		case value > upper+relaxationTol*math.Max(1, math.Abs(upper)):
+			relaxations = append(relaxations, BoundRelaxation{Row: row, Index: index, Lower: lower, Upper: value})
+		}
+	}
+	for row := 0; row < numRow; row++ {
+		add(true, row, model.RowLower[row], model.RowUpper[row], rowValue[row])
+	}
+	for col := 0; col < numCol; col++ {
+		add(false, col, model.ColLower[col], model.ColUpper[col], colValue[col])
+	}
+	return relaxations, nil
+}
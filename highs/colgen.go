@@ -0,0 +1,84 @@
+package highs
+
+import "fmt"
+
+// reducedCostTol is the reduced cost a generated column must improve on
+// to enter the master problem.
+const reducedCostTol = 1e-9
+
+// ColumnGeneration solves the LP master by column generation. It solves
+// the master, passes the constraint duals (RowDuals) to pricing, and adds
+// the column pricing returns, repeating until pricing reports that no
+// improving column exists by returning false. Each re-solve is warm
+// started from the previous basis.
+//
+// A returned column must have a negative reduced cost (positive when
+// maximizing), cost minus the duals times its coefficients; otherwise an
+// error is returned, since the loop would not make progress. Generated
+// columns are appended to master, so afterwards it holds the final master
+// problem and the returned solution covers all its columns. If a master
+// solve is not optimal, its solution is returned with an error.
+//
+// Only the options that set HiGHS options or direct its log apply to the
+// master solves: WithOutput, WithTimeLimit, WithThreads, WithPresolve,
+// the MIP settings, WithBoolOption and the other custom option setters,
+// WithLogWriter and WithSolveTrace. Options that act around a solve, such
+// as WithFixedValues, WithWarmStart, WithSecondaryObjective or
+// WithZeroThreshold, are ignored.
+func ColumnGeneration(master *Model, pricing func(duals []float64) (Column, bool), opts ...SolveOption) (*Solution, error) {
+	solver, err := NewSolver()
+	if err != nil {
+		return nil, err
+	}
+	defer solver.Close()
+
+	cfg := defaultSolveConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if err := cfg.apply(solver); err != nil {
+		return nil, err
+	}
+	if err := master.load(solver); err != nil {
+		return nil, err
+	}
+
+	for {
+		sol, err := solver.Run()
+		if err != nil {
+			return nil, err
+		}
+		if !sol.IsOptimal() {
+			return sol, newErrorMsg("ColumnGeneration", fmt.Sprintf("master solve ended with status %s", sol.Status))
+		}
+
+		col, ok := pricing(sol.RowDuals)
+		if !ok {
+			return sol, nil
+		}
+		if len(col.Rows) != len(col.Values) {
+			return nil, newErrorMsg("ColumnGeneration", "column Rows and Values must have same length")
+		}
+		reducedCost := col.Cost
+		for i, row := range col.Rows {
+			if row >= 0 && row < len(sol.RowDuals) {
+				reducedCost -= sol.RowDuals[row] * col.Values[i]
+			}
+		}
+		if master.Maximize {
+			reducedCost = -reducedCost
+		}
+		if reducedCost > -reducedCostTol {
+			return nil, newErrorMsg("ColumnGeneration", fmt.Sprintf("pricing returned a column with non-improving reduced cost %g", reducedCost))
+		}
+
+		if err := solver.AddCol(col.Cost, col.Lower, col.Upper, col.Rows, col.Values); err != nil {
+			return nil, err
+		}
+		index := master.NumVars()
+		master.addVar(index, col.Cost, col.Lower, col.Upper)
+		for i, row := range col.Rows {
+			master.ConstMatrix = append(master.ConstMatrix, Nonzero{Row: row, Col: index, Val: col.Values[i]})
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package highs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ModelDiff returns human-readable differences between two models.
+//
+// Both models are canonicalized before comparison: column and row slices
+// are padded with their defaults, and duplicate matrix entries are merged
+// (keeping the last value) with explicit zeros dropped. Models that only
+// differ in representation therefore compare equal.
+//
+// Example output:
+//
+//	ColCosts[1]: 1 != 2
+//	ConstMatrix[0,3]: 0 != 1.5
+func ModelDiff(a, b *Model) []string {
+	var diffs []string
+
+	if a.Maximize != b.Maximize {
+		diffs = append(diffs, fmt.Sprintf("Maximize: %t != %t", a.Maximize, b.Maximize))
+	}
+	if a.Offset != b.Offset {
+		diffs = append(diffs, fmt.Sprintf("Offset: %g != %g", a.Offset, b.Offset))
+	}
+
+	numColA, numColB := a.NumVars(), b.NumVars()
+	if numColA != numColB {
+		diffs = append(diffs, fmt.Sprintf("NumVars: %d != %d", numColA, numColB))
+	}
+	numRowA, numRowB := a.NumConstraints(), b.NumConstraints()
+	if numRowA != numRowB {
+		diffs = append(diffs, fmt.Sprintf("NumConstraints: %d != %d", numRowA, numRowB))
+	}
+	numCol := max(numColA, numColB)
+	numRow := max(numRowA, numRowB)
+
+	diffs = appendSliceDiffs(diffs, "ColCosts", numCol, a.ColCosts, b.ColCosts, 0.0)
+	diffs = appendSliceDiffs(diffs, "ColLower", numCol, a.ColLower, b.ColLower, math.Inf(-1))
+	diffs = appendSliceDiffs(diffs, "ColUpper", numCol, a.ColUpper, b.ColUpper, math.Inf(1))
+	diffs = appendSliceDiffs(diffs, "RowLower", numRow, a.RowLower, b.RowLower, math.Inf(-1))
+	diffs = appendSliceDiffs(diffs, "RowUpper", numRow, a.RowUpper, b.RowUpper, math.Inf(1))
+
+	for i := 0; i < numCol; i++ {
+		va, vb := Continuous, Continuous
+		if i < len(a.VarTypes) {
+			va = a.VarTypes[i]
+		}
+		if i < len(b.VarTypes) {
+			vb = b.VarTypes[i]
+		}
+		if va != vb {
+			diffs = append(diffs, fmt.Sprintf("VarTypes[%d]: %s != %s", i, va, vb))
+		}
+	}
+
+	diffs = appendMatrixDiffs(diffs, "ConstMatrix", a.ConstMatrix, b.ConstMatrix)
+	diffs = appendMatrixDiffs(diffs, "Hessian", a.Hessian, b.Hessian)
+
+	return diffs
+}
+
+// appendSliceDiffs compares two slices element-wise after padding both to
+// length n with fillValue.
+func appendSliceDiffs(diffs []string, name string, n int, a, b []float64, fillValue float64) []string {
+	for i := 0; i < n; i++ {
+		va, vb := fillValue, fillValue
+		if i < len(a) {
+			va = a[i]
+		}
+		if i < len(b) {
+			vb = b[i]
+		}
+		if va != vb {
+			diffs = append(diffs, fmt.Sprintf("%s[%d]: %g != %g", name, i, va, vb))
+		}
+	}
+	return diffs
+}
+
+// appendMatrixDiffs compares two sparse matrices entry by entry in
+// row-major order.
+func appendMatrixDiffs(diffs []string, name string, a, b []Nonzero) []string {
+	ma, mb := canonicalEntries(a), canonicalEntries(b)
+
+	keys := make([][2]int, 0, len(ma)+len(mb))
+	for k := range ma {
+		keys = append(keys, k)
+	}
+	for k := range mb {
+		if _, ok := ma[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	for _, k := range keys {
+		if ma[k] != mb[k] {
+			diffs = append(diffs, fmt.Sprintf("%s[%d,%d]: %g != %g", name, k[0], k[1], ma[k], mb[k]))
+		}
+	}
+	return diffs
+}
+
+// canonicalEntries merges duplicate entries (keeping the last value) and
+// drops explicit zeros.
+func canonicalEntries(nz []Nonzero) map[[2]int]float64 {
+	entries := make(map[[2]int]float64, len(nz))
+	for _, n := range nz {
+		entries[[2]int{n.Row, n.Col}] = n.Val
+	}
+	for k, v := range entries {
+		if v == 0 {
+			delete(entries, k)
+		}
+	}
+	return entries
+}
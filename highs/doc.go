@@ -0,0 +1,50 @@
+// Package highs provides Go bindings for the HiGHS linear optimization solver.
+//
+// HiGHS is a high-performance solver for linear programming (LP),
+// mixed-integer programming (MIP), and quadratic programming (QP) problems.
+//
+// This package embeds prebuilt static HiGHS libraries, so `go build` produces
+// a self-contained binary that does not require HiGHS to be installed.
+//
+// # Supported Platforms
+//
+//   - linux/amd64
+//   - linux/arm64
+//   - darwin/amd64
+//   - darwin/arm64
+//
+// The package requires cgo. On other platforms, or with cgo disabled, it
+// still compiles, but NewSolver and Model.Solve return
+// ErrUnsupportedPlatform.
+//
+// # High-Level API Example
+//
+// The high-level API uses the Model struct to define optimization problems:
+//
+//	model := highs.Model{
+//		ColCosts:  []float64{1.0, 1.0},
+//		ColLower:  []float64{0.0, 0.0},
+//		ColUpper:  []float64{10.0, 10.0},
+//	}
+//	model.AddDenseRow(1.0, []float64{1.0, 1.0}, 5.0) // 1 <= x + y <= 5
+//
+//	solution, err := model.Solve()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println("Optimal values:", solution.ColValues)
+//
+// # Low-Level API Example
+//
+// The low-level API provides direct access to the HiGHS solver:
+//
+//	solver, err := highs.NewSolver()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer solver.Close()
+//
+//	solver.SetBoolOption("output_flag", false)
+//	// ... add variables and constraints
+//	solution, err := solver.Run()
+package highs
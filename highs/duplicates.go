@@ -0,0 +1,145 @@
+package highs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DetectDuplicateRows returns groups of constraints whose coefficients are
+// structurally identical (same columns, same values), ignoring bounds.
+// Each group lists row indices in increasing order and has at least two
+// rows; groups are ordered by their first row.
+//
+// Duplicate matrix entries are merged (keeping the last value) and explicit
+// zeros dropped before comparison, as in ModelDiff. Rows that are scalar
+// multiples of each other are not considered duplicates.
+func (m *Model) DetectDuplicateRows() [][]int {
+	numRow := m.NumConstraints()
+	if numRow < 2 {
+		return nil
+	}
+
+	// Build a byte key of each row's sorted (col, value) pairs
+	cons := m.Constraints()
+	byKey := make(map[string][]int, numRow)
+	var keys []string
+	var buf []byte
+	for row, c := range cons {
+		buf = buf[:0]
+		for i, col := range c.Cols {
+			buf = binary.LittleEndian.AppendUint64(buf, uint64(col))
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(c.Coeffs[i]))
+		}
+		key := string(buf)
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], row)
+	}
+
+	var groups [][]int
+	for _, key := range keys {
+		if rows := byKey[key]; len(rows) > 1 {
+			groups = append(groups, rows)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0] < groups[j][0]
+	})
+	return groups
+}
+
+// MergeDuplicateRows collapses each group reported by DetectDuplicateRows
+// into its first row, whose bounds become the intersection of the group's
+// bounds. Later rows are removed and the remaining rows renumbered; the
+// kept row retains its RowGroups and RowNames entries.
+//
+// If a group's intersected bounds are contradictory (lower > upper), the
+// model is infeasible: an error is returned and the model is left unchanged.
+func (m *Model) MergeDuplicateRows() error {
+	groups := m.DetectDuplicateRows()
+	if len(groups) == 0 {
+		return nil
+	}
+
+	numRow := m.NumConstraints()
+	rowLower, err := expandSlice(numRow, m.RowLower, math.Inf(-1))
+	if err != nil {
+		return newErrorMsg("MergeDuplicateRows", "inconsistent RowLower length")
+	}
+	rowUpper, err := expandSlice(numRow, m.RowUpper, math.Inf(1))
+	if err != nil {
+		return newErrorMsg("MergeDuplicateRows", "inconsistent RowUpper length")
+	}
+	// Copy so a failed merge leaves the model untouched
+	rowLower = append([]float64(nil), rowLower...)
+	rowUpper = append([]float64(nil), rowUpper...)
+
+	removed := make([]bool, numRow)
+	for _, rows := range groups {
+		keep := rows[0]
+		for _, row := range rows[1:] {
+			rowLower[keep] = math.Max(rowLower[keep], rowLower[row])
+			rowUpper[keep] = math.Min(rowUpper[keep], rowUpper[row])
+			removed[row] = true
+		}
+		if rowLower[keep] > rowUpper[keep] {
+			return newErrorMsg("MergeDuplicateRows", fmt.Sprintf(
+				"duplicate rows %v have contradictory bounds [%g, %g]: model is infeasible",
+				rows, rowLower[keep], rowUpper[keep]))
+		}
+	}
+
+	// Renumber the surviving rows
+	newIndex := make([]int, numRow)
+	kept := make([]int, 0, numRow)
+	next := 0
+	for row := range newIndex {
+		if removed[row] {
+			newIndex[row] = -1
+			continue
+		}
+		kept = append(kept, row)
+		newIndex[row] = next
+		rowLower[next] = rowLower[row]
+		rowUpper[next] = rowUpper[row]
+		next++
+	}
+
+	matrix := make([]Nonzero, 0, len(m.ConstMatrix))
+	for _, nz := range m.ConstMatrix {
+		if newIndex[nz.Row] >= 0 {
+			nz.Row = newIndex[nz.Row]
+			matrix = append(matrix, nz)
+		}
+	}
+
+	m.keepRowAttrs(kept)
+	m.RowLower = rowLower[:next]
+	m.RowUpper = rowUpper[:next]
+	m.ConstMatrix = matrix
+	return nil
+}
+
+// keepRowAttrs reduces RowGroups and RowNames, when set, to the given
+// original rows in order.
+func (m *Model) keepRowAttrs(kept []int) {
+	keep := func(attrs []string) []string {
+		if len(attrs) == 0 {
+			return attrs
+		}
+		out := make([]string, 0, len(kept))
+		for _, row := range kept {
+			attr := ""
+			if row < len(attrs) {
+				attr = attrs[row]
+			}
+			out = append(out, attr)
+		}
+		return out
+	}
+	m.RowGroups = keep(m.RowGroups)
+	m.RowNames = keep(m.RowNames)
+}
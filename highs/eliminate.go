@@ -0,0 +1,206 @@
+package highs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Elimination records the columns removed by EliminateFixedVariables, so
+// a solution of the reduced model can be mapped back to the original.
+type Elimination struct {
+	// Kept holds the original index of each column of the reduced model.
+	Kept []int
+
+	// Fixed maps the original index of each eliminated column to the
+	// value it was fixed at.
+	Fixed map[int]float64
+
+	// NumCols is the number of columns of the original model.
+	NumCols int
+}
+
+// ExpandColValues returns the column values of the original model given
+// those of the reduced model, filling in the fixed values.
+func (e *Elimination) ExpandColValues(reduced []float64) []float64 {
+	values := make([]float64, e.NumCols)
+	for col, v := range e.Fixed {
+		values[col] = v
+	}
+	for i, col := range e.Kept {
+		if i < len(reduced) {
+			values[col] = reduced[i]
+		}
+	}
+	return values
+}
+
+// EliminateFixedVariables removes the columns whose lower and upper
+// bounds are equal, renumbering the rest. Each removed column's
+// contribution moves into the model: its cost and diagonal Hessian term
+// into Offset, its constraint coefficients into the row bounds, and its
+// off-diagonal Hessian terms into the costs of the columns they pair it
+// with, so the reduced model has the same optimal objective. Duplicate
+// matrix and Hessian entries are merged first, keeping the last value.
+// Semi-continuous and semi-integer columns are never eliminated, since
+// they may also take the value zero.
+//
+// It returns an error, leaving the model unchanged, if an integer column
+// is fixed at a fractional value, which makes the model infeasible.
+func (m *Model) EliminateFixedVariables() (*Elimination, error) {
+	numCol := m.NumVars()
+	colCosts, err := expandSlice(numCol, m.ColCosts, 0)
+	if err != nil {
+		return nil, newErrorMsg("EliminateFixedVariables", "inconsistent ColCosts length")
+	}
+	colLower, err := expandSlice(numCol, m.ColLower, math.Inf(-1))
+	if err != nil {
+		return nil, newErrorMsg("EliminateFixedVariables", "inconsistent ColLower length")
+	}
+	colUpper, err := expandSlice(numCol, m.ColUpper, math.Inf(1))
+	if err != nil {
+		return nil, newErrorMsg("EliminateFixedVariables", "inconsistent ColUpper length")
+	}
+	numRow := m.NumConstraints()
+	rowLower, err := expandSlice(numRow, m.RowLower, math.Inf(-1))
+	if err != nil {
+		return nil, newErrorMsg("EliminateFixedVariables", "inconsistent RowLower length")
+	}
+	rowUpper, err := expandSlice(numRow, m.RowUpper, math.Inf(1))
+	if err != nil {
+		return nil, newErrorMsg("EliminateFixedVariables", "inconsistent RowUpper length")
+	}
+
+	elim := &Elimination{Fixed: make(map[int]float64), NumCols: numCol}
+	newIndex := make([]int, numCol)
+	for col := range newIndex {
+		varType := Continuous
+		if col < len(m.VarTypes) {
+			varType = m.VarTypes[col]
+		}
+		v := colLower[col]
+		if v != colUpper[col] || !isFiniteBound(v) || (varType != Continuous && varType != Integer) {
+			newIndex[col] = len(elim.Kept)
+			elim.Kept = append(elim.Kept, col)
+			continue
+		}
+		if varType == Integer && v != math.Round(v) {
+			return nil, newErrorMsg("EliminateFixedVariables", fmt.Sprintf(
+				"integer column %d is fixed at fractional value %g: model is infeasible", col, v))
+		}
+		newIndex[col] = -1
+		elim.Fixed[col] = v
+	}
+	if len(elim.Fixed) == 0 {
+		return elim, nil
+	}
+
+	// Copy so the caller's slices are not modified in place
+	costs := append([]float64(nil), colCosts...)
+	rowLower = append([]float64(nil), rowLower...)
+	rowUpper = append([]float64(nil), rowUpper...)
+	offset := m.Offset
+	for col := range costs {
+		if v, fixed := elim.Fixed[col]; fixed {
+			offset += costs[col] * v
+		}
+	}
+
+	var matrix []Nonzero
+	for _, nz := range sortedEntries(m.ConstMatrix) {
+		v, fixed := elim.Fixed[nz.Col]
+		if !fixed {
+			nz.Col = newIndex[nz.Col]
+			matrix = append(matrix, nz)
+			continue
+		}
+		rowLower[nz.Row] -= nz.Val * v
+		rowUpper[nz.Row] -= nz.Val * v
+	}
+
+	var hessian []Nonzero
+	for _, nz := range sortedEntries(m.Hessian) {
+		vi, fixedRow := elim.Fixed[nz.Row]
+		vj, fixedCol := elim.Fixed[nz.Col]
+		switch {
+		case fixedRow && fixedCol && nz.Row == nz.Col:
+			offset += 0.5 * nz.Val * vi * vi
+		case fixedRow && fixedCol:
+			offset += nz.Val * vi * vj
+		case fixedRow:
+			costs[nz.Col] += nz.Val * vi
+		case fixedCol:
+			costs[nz.Row] += nz.Val * vj
+		default:
+			nz.Row, nz.Col = newIndex[nz.Row], newIndex[nz.Col]
+			hessian = append(hessian, nz)
+		}
+	}
+
+	keep := func(n int) bool { return n < numCol && newIndex[n] >= 0 }
+	m.ColCosts = compactFloat64s(costs, keep)
+	m.ColLower = compactFloat64s(colLower, keep)
+	m.ColUpper = compactFloat64s(colUpper, keep)
+	m.keepColumnAttrs(elim.Kept)
+	m.RowLower = rowLower
+	m.RowUpper = rowUpper
+	m.ConstMatrix = matrix
+	m.Hessian = hessian
+	m.Offset = offset
+	return elim, nil
+}
+
+// keepColumnAttrs reduces VarTypes and ColNames, when set, to the given
+// original columns in order.
+func (m *Model) keepColumnAttrs(kept []int) {
+	if len(m.VarTypes) > 0 {
+		varTypes := make([]VariableType, 0, len(kept))
+		for _, col := range kept {
+			if col < len(m.VarTypes) {
+				varTypes = append(varTypes, m.VarTypes[col])
+			} else {
+				varTypes = append(varTypes, Continuous)
+			}
+		}
+		m.VarTypes = varTypes
+	}
+	if len(m.ColNames) > 0 {
+		names := make([]string, 0, len(kept))
+		for _, col := range kept {
+			name := ""
+			if col < len(m.ColNames) {
+				name = m.ColNames[col]
+			}
+			names = append(names, name)
+		}
+		m.ColNames = names
+	}
+}
+
+// sortedEntries merges duplicate entries (keeping the last value) and
+// returns them sorted by row and column, dropping explicit zeros.
+func sortedEntries(nz []Nonzero) []Nonzero {
+	entries := canonicalEntries(nz)
+	sorted := make([]Nonzero, 0, len(entries))
+	for k, v := range entries {
+		sorted = append(sorted, Nonzero{Row: k[0], Col: k[1], Val: v})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Row != sorted[j].Row {
+			return sorted[i].Row < sorted[j].Row
+		}
+		return sorted[i].Col < sorted[j].Col
+	})
+	return sorted
+}
+
+// compactFloat64s returns the elements of v whose index satisfies keep.
+func compactFloat64s(v []float64, keep func(int) bool) []float64 {
+	out := make([]float64, 0, len(v))
+	for i, x := range v {
+		if keep(i) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+//go:build cgo && (linux || darwin) && (amd64 || arm64)
+
+package highs
+
+import (
+	"fmt"
+	"math"
+)
+
+// SetSolutionFilter registers a function that accepts (true) or rejects
+// (false) each optimal MIP solution found by Run, e.g. to enforce a
+// constraint that is too expensive to encode. Pass nil to remove it.
+//
+// HiGHS has no callback for rejecting solutions during the search, so
+// rejection is implemented by re-solving: a no-good cut excluding the
+// rejected assignment of the integer variables is added as a new row and
+// the model is solved again, until a solution is accepted or the model
+// becomes infeasible. Every integer variable must be binary (bounds within
+// [0, 1]). The cuts are deleted before Run returns, on success or error,
+// so the solver again holds the model that was loaded and the per-row
+// fields of the Solution cover its rows only. Deleting them clears the
+// solver's own copy of the solution.
+//
+// Only optimal solutions are filtered; any other status is returned as is.
+// The filter must not retain values.
+func (s *Solver) SetSolutionFilter(filter func(values []float64) bool) {
+	s.solutionFilter = filter
+}
+
+// runFiltered solves repeatedly, adding a no-good cut for each solution
+// the filter rejects, and deletes the cuts again before it returns.
+func (s *Solver) runFiltered(sol *Solution) (err error) {
+	model, err := s.GetModel()
+	if err != nil {
+		return err
+	}
+	numRow := s.NumRow()
+	defer func() {
+		if cuts := s.NumRow(); cuts > numRow {
+			if derr := s.DeleteRowsByRange(numRow, cuts-1); derr != nil && err == nil {
+				err = derr
+			}
+		}
+		sol.trimRows(numRow)
+	}()
+	var binaries []int
+	for col, t := range model.VarTypes {
+		if t == Continuous {
+			continue
+		}
+		if t != Integer || model.ColLower[col] < 0 || model.ColUpper[col] > 1 {
+			return newErrorMsg("Run", fmt.Sprintf("solution filter requires binary integer variables, column %d is not", col))
+		}
+		binaries = append(binaries, col)
+	}
+
+	index := make([]int, len(binaries))
+	value := make([]float64, len(binaries))
+	for {
+		if err := s.runInto(sol); err != nil {
+			return err
+		}
+		if !sol.IsOptimal() || s.solutionFilter(sol.ColValues) {
+			return nil
+		}
+		if len(binaries) == 0 {
+			return newErrorMsg("Run", "solution filter rejected a solution of a model without integer variables")
+		}
+
+		// Σ_{x*=0} x_i + Σ_{x*=1} (1 - x_i) >= 1
+		lower := 1.0
+		for i, col := range binaries {
+			index[i] = col
+			if math.Round(sol.ColValues[col]) == 1 {
+				value[i] = -1
+				lower--
+			} else {
+				value[i] = 1
+			}
+		}
+		if err := s.AddRow(lower, math.Inf(1), index, value); err != nil {
+			return err
+		}
+	}
+}
+
+// trimRows drops the entries of the per-row fields past the first n rows.
+func (sol *Solution) trimRows(n int) {
+	if len(sol.RowValues) > n {
+		sol.RowValues = sol.RowValues[:n]
+	}
+	if len(sol.RowDuals) > n {
+		sol.RowDuals = sol.RowDuals[:n]
+	}
+	if len(sol.RowBasis) > n {
+		sol.RowBasis = sol.RowBasis[:n]
+	}
+	if len(sol.RowViolations) > n {
+		sol.RowViolations = sol.RowViolations[:n]
+	}
+}
@@ -1,7 +1,21 @@
+//go:build cgo && (linux || darwin) && (amd64 || arm64)
+
 package highs
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -53,6 +67,180 @@ func TestLP(t *testing.T) {
 	}
 }
 
+// TestRankByReducedCost tests ordering columns by the magnitude of their
+// reduced costs.
+func TestRankByReducedCost(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	ranking := sol.RankByReducedCost()
+	if len(ranking) != len(sol.ColDuals) {
+		t.Fatalf("len(RankByReducedCost) = %d, expected %d", len(ranking), len(sol.ColDuals))
+	}
+	for i := 1; i < len(ranking); i++ {
+		if math.Abs(sol.ColDuals[ranking[i]]) < math.Abs(sol.ColDuals[ranking[i-1]]) {
+			t.Errorf("Ranking %v not ordered by |reduced cost| %v", ranking, sol.ColDuals)
+		}
+	}
+
+	ranked := (&Solution{ColDuals: []float64{-3.0, 0.0, 1.5, -1.5}}).RankByReducedCost()
+	if !reflect.DeepEqual(ranked, []int{1, 2, 3, 0}) {
+		t.Errorf("RankByReducedCost = %v, expected [1 2 3 0]", ranked)
+	}
+}
+
+// TestShadowPrice tests that shadow prices on the TestLP model follow the
+// objective change rather than the sense.
+func TestShadowPrice(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	// Raising either binding lower bound by one moves x by (-0.5, 0.75)
+	// or (0.5, -0.25), so the objective rises by 0.25; row 0 is slack
+	for row, expected := range []float64{0, 0.25, 0.25} {
+		if got := sol.ShadowPrice(&model, row); !almostEqual(got, expected, 1e-9) {
+			t.Errorf("ShadowPrice(%d) = %v, expected %v", row, got, expected)
+		}
+	}
+	negated := model
+	negated.Maximize = true
+	negated.ColCosts = []float64{-1.0, -1.0}
+	nsol, err := negated.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve negated failed: %v", err)
+	}
+	if got := nsol.ShadowPrice(&negated, 1); !almostEqual(got, -0.25, 1e-9) {
+		t.Errorf("Maximized ShadowPrice(1) = %v, expected -0.25", got)
+	}
+}
+
+// TestRHSRanges tests right-hand side ranging on the TestLP model.
+func TestRHSRanges(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	// Each right-hand side lies within its range; slack row 0 can drop
+	// to its activity, 2.25
+	ranges, err := sol.RHSRanges(&model)
+	if err != nil {
+		t.Fatalf("RHSRanges failed: %v", err)
+	}
+	expected := []RHSRange{{7, 2.25, math.Inf(1)}, {5, 10.0 / 3, 6}, {6, 5, 11}}
+	if len(ranges) != len(expected) {
+		t.Fatalf("len(RHSRanges) = %d, expected %d", len(ranges), len(expected))
+	}
+	for row, r := range ranges {
+		if r.Lower > r.Bound || r.Bound > r.Upper {
+			t.Errorf("row %d: range [%v, %v] does not bracket %v", row, r.Lower, r.Upper, r.Bound)
+		}
+		e := expected[row]
+		if !almostEqual(r.Bound, e.Bound, 1e-9) || !almostEqual(r.Lower, e.Lower, 1e-9) ||
+			(r.Upper != e.Upper && !almostEqual(r.Upper, e.Upper, 1e-9)) {
+			t.Errorf("row %d: range %+v, expected %+v", row, r, e)
+		}
+	}
+}
+
+// TestGetCoeff tests reading single matrix coefficients of the TestLP
+// model.
+func TestGetCoeff(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	for _, nz := range []Nonzero{{2, 0, 3.0}, {1, 1, 2.0}, {0, 0, 0}} {
+		if got, err := solver.GetCoeff(nz.Row, nz.Col); err != nil || got != nz.Val {
+			t.Errorf("GetCoeff(%d, %d) = %v (err %v), expected %v", nz.Row, nz.Col, got, err, nz.Val)
+		}
+	}
+	if _, err := solver.GetCoeff(3, 0); err == nil {
+		t.Error("Expected an error for an out-of-range row")
+	}
+}
+
 // TestLPMaximize tests a maximization LP problem.
 func TestLPMaximize(t *testing.T) {
 	model := Model{
@@ -132,6 +320,44 @@ func TestMIP(t *testing.T) {
 	}
 }
 
+// TestWithRootRelaxationBound tests recording the LP relaxation
+// objective of the TestMIP model.
+func TestWithRootRelaxationBound(t *testing.T) {
+	model := Model{
+		Maximize: true,
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+		VarTypes: []VariableType{Integer, Integer},
+	}
+
+	// The relaxation bound of a maximization is at least the integer objective
+	sol, err := model.Solve(WithOutput(false), WithRootRelaxationBound())
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	bound := sol.Info.RootRelaxationObjective
+	if bound < sol.Objective-1e-9 {
+		t.Errorf("RootRelaxationObjective = %f, expected >= %f", bound, sol.Objective)
+	}
+	if !almostEqual(bound, 12.5, 0.01) {
+		t.Errorf("RootRelaxationObjective = %f, expected 12.5", bound)
+	}
+	if !almostEqual(sol.Objective, 12.0, 0.01) {
+		t.Errorf("Objective = %f after relaxation, expected 12.0", sol.Objective)
+	}
+}
+
 // TestQP tests a quadratic programming problem.
 //
 //	minimize -x_2 - 3x_3 + (1/2)(2x_1^2 - 2x_1x_3 + 0.2x_2^2 + 2x_3^2)
@@ -176,6 +402,118 @@ func TestQP(t *testing.T) {
 	}
 }
 
+// TestObjectiveGradient tests that the gradient at the TestQP optimum
+// satisfies KKT stationarity.
+func TestObjectiveGradient(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{0.0, -1.0, -3.0},
+		ConstMatrix: []Nonzero{
+			{0, 0, 1.0},
+			{0, 2, 1.0},
+		},
+		RowLower: []float64{-1e30},
+		RowUpper: []float64{2.0},
+		Hessian: []Nonzero{
+			{0, 0, 2.0},
+			{0, 2, -1.0},
+			{1, 1, 0.2},
+			{2, 2, 2.0},
+		},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	// KKT stationarity: c + Q·x = A'·y + z
+	grad := sol.ObjectiveGradient(&model)
+	if len(grad) != 3 {
+		t.Fatalf("ObjectiveGradient length = %d, expected 3", len(grad))
+	}
+	if !almostEqual(grad[0], -0.5, 1e-5) || !almostEqual(grad[2], -0.5, 1e-5) {
+		t.Errorf("gradient = %v, expected [-0.5 0 -0.5]", grad)
+	}
+	dual := append([]float64(nil), sol.ColDuals...)
+	for _, nz := range model.ConstMatrix {
+		dual[nz.Col] += nz.Val * sol.RowDuals[nz.Row]
+	}
+	for i := range grad {
+		if !almostEqual(grad[i], dual[i], 1e-6) {
+			t.Errorf("gradient[%d] = %g, A'y + z = %g", i, grad[i], dual[i])
+		}
+	}
+}
+
+// TestAddQuadraticTerm reproduces TestQP with the objective's quadratic
+// part written as plain coefficients.
+func TestAddQuadraticTerm(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{0.0, -1.0, -3.0},
+		ConstMatrix: []Nonzero{
+			{0, 0, 1.0},
+			{0, 2, 1.0},
+		},
+		RowLower: []float64{-1e30},
+		RowUpper: []float64{2.0},
+	}
+	// x0² - x0*x2 + 0.1*x1² + x2², with the last split in two parts
+	model.AddQuadraticTerm(0, 0, 1.0)
+	model.AddQuadraticTerm(2, 0, -1.0)
+	model.AddQuadraticTerm(1, 1, 0.1)
+	model.AddQuadraticTerm(2, 2, 0.25)
+	model.AddQuadraticTerm(2, 2, 0.75)
+
+	want := []Nonzero{{0, 0, 2.0}, {0, 2, -1.0}, {1, 1, 0.2}, {2, 2, 2.0}}
+	if !reflect.DeepEqual(model.Hessian, want) {
+		t.Errorf("Hessian = %v, expected %v", model.Hessian, want)
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, -5.25, 0.01) {
+		t.Errorf("Objective = %f (%s), expected -5.25", sol.Objective, sol.Status)
+	}
+	for col, v := range []float64{0.5, 5.0, 1.5} {
+		if !almostEqual(sol.ColValues[col], v, 0.01) {
+			t.Errorf("x%d = %f, expected %v", col, sol.ColValues[col], v)
+		}
+	}
+
+	// Terms accumulate into entries set directly, in either triangle
+	model.Hessian = append(model.Hessian, Nonzero{Row: 1, Col: 0, Val: 0.5})
+	model.AddQuadraticTerm(0, 1, 0.25)
+	model.AddQuadraticTerm(1, 0, 0.25)
+	if n := len(model.Hessian); n != 5 || model.Hessian[4].Val != 1.0 {
+		t.Errorf("Hessian = %v, expected (1, 0) accumulated to 1", model.Hessian)
+	}
+
+	// A copy accumulates into its own entries
+	copied := model
+	copied.Hessian = append([]Nonzero(nil), model.Hessian...)
+	copied.AddQuadraticTerm(1, 1, 1.0)
+	model.AddQuadraticTerm(2, 1, 1.0)
+	copied.AddQuadraticTerm(2, 1, 1.0)
+	if copied.Hessian[2].Val != 2.2 || model.Hessian[2].Val != 0.2 {
+		t.Errorf("Hessian (1, 1) = %v in the copy and %v in the original, expected 2.2 and 0.2",
+			copied.Hessian[2].Val, model.Hessian[2].Val)
+	}
+	if len(copied.Hessian) != 6 || len(model.Hessian) != 6 {
+		t.Errorf("Hessian lengths = %d and %d, expected 6 each", len(copied.Hessian), len(model.Hessian))
+	}
+
+	// Negative indices are left for Validate to reject
+	model.AddQuadraticTerm(-1, 1, 1.0)
+	if err := model.Validate(); err == nil {
+		t.Error("Expected Validate to reject a negative Hessian index")
+	}
+}
+
 // TestAddDenseRow tests the AddDenseRow convenience method.
 func TestAddDenseRow(t *testing.T) {
 	model := Model{
@@ -205,6 +543,33 @@ func TestAddDenseRow(t *testing.T) {
 	}
 }
 
+// TestRowHelpers tests that the ranged and sparse row helpers build the
+// same rows as AddDenseRow and AddSparseRow.
+func TestRowHelpers(t *testing.T) {
+	var got, want Model
+	got.AddRangeRow([]float64{1, 0, 2}, -1, 4)
+	want.AddDenseRow(-1, []float64{1, 0, 2}, 4)
+
+	cols, vals := []int{2, 0}, []float64{3, -1}
+	got.AddLeRowSparse(cols, vals, 5)
+	want.AddSparseRow(math.Inf(-1), cols, vals, 5)
+	got.AddGeRowSparse(cols, vals, 1)
+	want.AddSparseRow(1, cols, vals, math.Inf(1))
+	got.AddEqRowSparse(cols, vals, 2)
+	want.AddSparseRow(2, cols, vals, 2)
+
+	if !reflect.DeepEqual(got.ConstMatrix, want.ConstMatrix) {
+		t.Errorf("ConstMatrix = %v, expected %v", got.ConstMatrix, want.ConstMatrix)
+	}
+	if !reflect.DeepEqual(got.RowLower, want.RowLower) || !reflect.DeepEqual(got.RowUpper, want.RowUpper) {
+		t.Errorf("row bounds = %v, %v, expected %v, %v", got.RowLower, got.RowUpper, want.RowLower, want.RowUpper)
+	}
+	if !reflect.DeepEqual(got.RowLower, []float64{-1, math.Inf(-1), 1, 2}) ||
+		!reflect.DeepEqual(got.RowUpper, []float64{4, 5, math.Inf(1), 2}) {
+		t.Errorf("row bounds = %v, %v, expected [-1 -Inf 1 2], [4 5 +Inf 2]", got.RowLower, got.RowUpper)
+	}
+}
+
 // TestLowLevelAPI tests the low-level solver API.
 func TestLowLevelAPI(t *testing.T) {
 	solver, err := NewSolver()
@@ -309,6 +674,43 @@ func TestEmptyModel(t *testing.T) {
 	}
 }
 
+// TestPopulated tests that Populated is set for a solved model and left
+// unset for an empty one.
+func TestPopulated(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.Populated {
+		t.Error("Expected Populated to be true")
+	}
+
+	empty := Model{}
+	sol, err = empty.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Populated {
+		t.Error("Expected Populated to be false for empty model")
+	}
+}
+
 // TestInfeasible tests detection of infeasible models.
 func TestInfeasible(t *testing.T) {
 	model := Model{
@@ -331,12 +733,151 @@ func TestInfeasible(t *testing.T) {
 	}
 }
 
-func TestSolverInfinity(t *testing.T) {
-	solver, err := NewSolver()
-	if err != nil {
-		t.Fatalf("NewSolver failed: %v", err)
+// TestDiagnoseInfeasibility tests the conflicting rows and relaxation
+// reported for the TestInfeasible model.
+func TestDiagnoseInfeasibility(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1.0},
+		ColLower: []float64{0.0},
+		ColUpper: []float64{10.0},
 	}
-	defer solver.Close()
+	// x >= 5
+	model.AddDenseRow(5.0, []float64{1.0}, math.Inf(1))
+	// x <= 3
+	model.AddDenseRow(math.Inf(-1), []float64{1.0}, 3.0)
+
+	report, err := model.DiagnoseInfeasibility(WithOutput(false))
+	if err != nil {
+		t.Fatalf("DiagnoseInfeasibility failed: %v", err)
+	}
+	if report == nil {
+		t.Fatal("Expected a report for an infeasible model")
+	}
+	if !reflect.DeepEqual(report.ConflictingRows, []int{0, 1}) {
+		t.Errorf("ConflictingRows = %v, expected [0 1]", report.ConflictingRows)
+	}
+	if len(report.Relaxations) != 1 {
+		t.Fatalf("Relaxations = %+v, expected one", report.Relaxations)
+	}
+	r := report.Relaxations[0]
+	if !r.Row || (r.Index == 0 && r.Lower > 3.0+1e-6) || (r.Index == 1 && r.Upper < 5.0-1e-6) {
+		t.Errorf("Relaxation %+v does not resolve the conflict", r)
+	}
+
+	// Computing an IIS leaves the solver's iis_strategy as it was
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	strategy, err := solver.GetIntOption("iis_strategy")
+	if err != nil {
+		t.Fatalf("GetIntOption failed: %v", err)
+	}
+	if rows, _, err := solver.getIIS(); err != nil || !reflect.DeepEqual(rows, []int{0, 1}) {
+		t.Errorf("getIIS = %v, %v, expected rows [0 1]", rows, err)
+	}
+	if got, err := solver.GetIntOption("iis_strategy"); err != nil || got != strategy {
+		t.Errorf("iis_strategy = %d (err %v) after getIIS, expected %d", got, err, strategy)
+	}
+
+	model.RowUpper[1] = 8.0
+	if report, err := model.DiagnoseInfeasibility(WithOutput(false)); err != nil || report != nil {
+		t.Errorf("Feasible model: report %+v, err %v, expected nil", report, err)
+	}
+}
+
+// TestWithRowViolations tests that an infeasible model reports how far
+// each constraint is from its bounds.
+func TestWithRowViolations(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1.0},
+		ColLower: []float64{0.0},
+		ColUpper: []float64{10.0},
+	}
+	// x >= 5
+	model.AddDenseRow(5.0, []float64{1.0}, math.Inf(1))
+	// x <= 3
+	model.AddDenseRow(math.Inf(-1), []float64{1.0}, 3.0)
+
+	// x must move 2 units to satisfy either row, so the rows' violations
+	// total 2 however they are split
+	sol, err := model.Solve(WithOutput(false), WithRowViolations())
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsInfeasible() {
+		t.Errorf("Expected infeasible, got %s", sol.Status)
+	}
+	if len(sol.RowViolations) != 2 {
+		t.Fatalf("RowViolations = %v, expected two entries", sol.RowViolations)
+	}
+	for row, v := range sol.RowViolations {
+		if v < 0 || v > 2+1e-6 {
+			t.Errorf("RowViolations[%d] = %v, expected within [0, 2]", row, v)
+		}
+	}
+	if total := sol.RowViolations[0] + sol.RowViolations[1]; !almostEqual(total, 2, 1e-6) {
+		t.Errorf("RowViolations = %v, expected a total of 2", sol.RowViolations)
+	}
+
+	// Column bounds and integrality are kept, so infeasibilities they
+	// cause are not attributed to the rows
+	for _, tc := range []struct {
+		name  string
+		model Model
+	}{
+		{"column bounds", Model{
+			ColCosts:    []float64{1},
+			ColLower:    []float64{5},
+			ColUpper:    []float64{3},
+			ConstMatrix: []Nonzero{{0, 0, 1}},
+			RowLower:    []float64{0},
+			RowUpper:    []float64{10},
+		}},
+		{"integrality", Model{
+			ColCosts:    []float64{1},
+			ColLower:    []float64{0},
+			ColUpper:    []float64{10},
+			ConstMatrix: []Nonzero{{0, 0, 1}},
+			RowLower:    []float64{1.2},
+			RowUpper:    []float64{1.8},
+			VarTypes:    []VariableType{Integer},
+		}},
+	} {
+		sol, err := tc.model.Solve(WithOutput(false), WithRowViolations())
+		if err != nil {
+			t.Fatalf("%s: Solve failed: %v", tc.name, err)
+		}
+		if !sol.IsInfeasible() {
+			t.Errorf("%s: expected infeasible, got %s", tc.name, sol.Status)
+		}
+		if sol.RowViolations != nil {
+			t.Errorf("%s: RowViolations = %v, expected nil", tc.name, sol.RowViolations)
+		}
+	}
+
+	model.RowUpper[1] = 8.0
+	if sol, err = model.Solve(WithOutput(false), WithRowViolations()); err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.RowViolations != nil {
+		t.Errorf("Feasible model: RowViolations = %v, expected nil", sol.RowViolations)
+	}
+}
+
+func TestSolverInfinity(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
 
 	inf := solver.Infinity()
 	if inf <= 0 || math.IsNaN(inf) {
@@ -344,6 +885,4298 @@ func TestSolverInfinity(t *testing.T) {
 	}
 }
 
+// TestInfinityValue tests that the cached and package-level infinity
+// values match the one HiGHS reports.
+func TestInfinityValue(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	inf := solver.Infinity()
+	if cached, fresh := inf, solver.getInfinity(); cached != fresh {
+		t.Errorf("Cached infinity %g != fresh %g", cached, fresh)
+	}
+	if InfinityValue() != inf {
+		t.Errorf("InfinityValue = %g, expected %g", InfinityValue(), inf)
+	}
+}
+
+// TestAllContinuous tests relaxing a MIP by marking every column continuous.
+func TestAllContinuous(t *testing.T) {
+	model := Model{
+		Maximize: true,
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+		VarTypes: []VariableType{Integer, Integer},
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	varTypes, err := solver.Integralities()
+	if err != nil {
+		t.Fatalf("Integralities failed: %v", err)
+	}
+	for i, vt := range varTypes {
+		if vt != Integer {
+			t.Errorf("col %d = %s, expected Integer", i, vt)
+		}
+	}
+
+	if err := solver.AllContinuous(); err != nil {
+		t.Fatalf("AllContinuous failed: %v", err)
+	}
+	varTypes, err = solver.Integralities()
+	if err != nil {
+		t.Fatalf("Integralities failed: %v", err)
+	}
+	for i, vt := range varTypes {
+		if vt != Continuous {
+			t.Errorf("col %d = %s, expected Continuous", i, vt)
+		}
+	}
+
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	// The relaxation matches TestLPMaximize rather than TestMIP.
+	if !almostEqual(sol.Objective, 12.5, 0.01) {
+		t.Errorf("Objective = %f, expected 12.5", sol.Objective)
+	}
+
+	if err := solver.AllInteger(); err != nil {
+		t.Fatalf("AllInteger failed: %v", err)
+	}
+	sol, err = solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !almostEqual(sol.Objective, 12.0, 0.01) {
+		t.Errorf("Objective = %f, expected 12.0", sol.Objective)
+	}
+}
+
+// TestModelDiff tests that a single altered coefficient is reported once.
+func TestModelDiff(t *testing.T) {
+	a := Model{
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 0.0},
+		ColUpper: []float64{10.0, 10.0},
+	}
+	a.AddDenseRow(1.0, []float64{1.0, 1.0}, 5.0)
+	a.AddDenseRow(2.0, []float64{3.0, 1.0}, 8.0)
+
+	b := a
+	b.ConstMatrix = append([]Nonzero(nil), a.ConstMatrix...)
+	b.ConstMatrix[2].Val = 4.0
+
+	if diffs := ModelDiff(&a, &a); len(diffs) != 0 {
+		t.Errorf("Expected no differences, got %v", diffs)
+	}
+
+	diffs := ModelDiff(&a, &b)
+	if len(diffs) != 1 {
+		t.Fatalf("Expected 1 difference, got %v", diffs)
+	}
+	if diffs[0] != "ConstMatrix[1,0]: 3 != 4" {
+		t.Errorf("Unexpected difference: %s", diffs[0])
+	}
+}
+
+// TestVerifyIntegrality tests that a solved MIP reports no integrality violations.
+func TestVerifyIntegrality(t *testing.T) {
+	model := Model{
+		Maximize: true,
+		VarTypes: []VariableType{Integer, Integer, Integer},
+		ColCosts: []float64{1.0, 1.0, 1.0},
+		ColLower: []float64{1.0, 1.0, 1.0},
+		ColUpper: []float64{6.0, 6.0, 6.0},
+	}
+	model.AddDenseRow(0.0, []float64{1.0, -3.0, 2.0}, 0.0)
+	model.AddDenseRow(1.0, []float64{0.0, 1.0, -1.0}, math.Inf(1))
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if _, err := solver.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	ok, violations, err := solver.VerifyIntegrality(1e-6)
+	if err != nil {
+		t.Fatalf("VerifyIntegrality failed: %v", err)
+	}
+	if !ok || len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+}
+
+// TestNewSolverNoFinalizer tests that the finalizer-free solver still closes cleanly.
+func TestNewSolverNoFinalizer(t *testing.T) {
+	solver, err := NewSolverNoFinalizer()
+	if err != nil {
+		t.Fatalf("NewSolverNoFinalizer failed: %v", err)
+	}
+	// SetFinalizer panics if the solver already has a finalizer
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Expected no finalizer to be set: %v", r)
+			}
+		}()
+		runtime.SetFinalizer(solver, func(*Solver) {})
+		runtime.SetFinalizer(solver, nil)
+	}()
+	if inf := solver.Infinity(); inf <= 0 {
+		t.Errorf("Invalid infinity value: %f", inf)
+	}
+
+	solver.Close()
+	if solver.ptr != nil {
+		t.Error("Expected Close to release the HiGHS instance")
+	}
+	solver.Close()
+}
+
+// TestMIPTuningOptions tests the typed MIP tuning options.
+func TestMIPTuningOptions(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	cfg := defaultSolveConfig()
+	for _, opt := range []SolveOption{
+		WithMIPHeuristicEffort(0.3),
+		WithMIPDetectSymmetry(false),
+		WithMIPMaxImprovingSols(7),
+	} {
+		opt(cfg)
+	}
+	if err := cfg.apply(solver); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	effort, err := solver.GetFloatOption("mip_heuristic_effort")
+	if err != nil {
+		t.Fatalf("GetFloatOption failed: %v", err)
+	}
+	if effort != 0.3 {
+		t.Errorf("mip_heuristic_effort = %f, expected 0.3", effort)
+	}
+	symmetry, err := solver.GetBoolOption("mip_detect_symmetry")
+	if err != nil {
+		t.Fatalf("GetBoolOption failed: %v", err)
+	}
+	if symmetry {
+		t.Error("mip_detect_symmetry = true, expected false")
+	}
+	sols, err := solver.GetIntOption("mip_max_improving_sols")
+	if err != nil {
+		t.Fatalf("GetIntOption failed: %v", err)
+	}
+	if sols != 7 {
+		t.Errorf("mip_max_improving_sols = %d, expected 7", sols)
+	}
+
+	model := Model{ColCosts: []float64{1.0}, ColLower: []float64{0.0}}
+	if _, err := model.Solve(WithOutput(false), WithMIPHeuristicEffort(1.5)); err == nil {
+		t.Error("Expected error for out-of-range heuristic effort")
+	}
+	if _, err := model.Solve(WithOutput(false), WithMIPMaxImprovingSols(0)); err == nil {
+		t.Error("Expected error for non-positive improving solution count")
+	}
+}
+
+// TestSolutionCache tests that cache hits skip the solver and that the
+// least recently used entry is evicted.
+func TestSolutionCache(t *testing.T) {
+	cache := NewSolutionCache(2)
+	calls := 0
+	cache.solve = func(m *Model, opts ...SolveOption) (*Solution, error) {
+		calls++
+		return m.Solve(opts...)
+	}
+
+	model := Model{
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 0.0},
+		ColUpper: []float64{10.0, 10.0},
+	}
+	model.AddDenseRow(1.0, []float64{1.0, 1.0}, 5.0)
+
+	first, err := cache.Solve(&model, WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	second, err := cache.Solve(&model, WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("solver called %d times, expected 1", calls)
+	}
+	if first != second {
+		t.Error("Expected the cached solution to be returned")
+	}
+	if hits, misses := cache.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("Stats = (%d, %d), expected (1, 1)", hits, misses)
+	}
+
+	other := model
+	other.ColCosts = []float64{2.0, 1.0}
+	third := model
+	third.ColCosts = []float64{3.0, 1.0}
+	cache.Put(&other, &Solution{})
+	cache.Put(&third, &Solution{})
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d, expected 2", cache.Len())
+	}
+	if _, ok := cache.Get(&model); ok {
+		t.Error("Expected least recently used model to be evicted")
+	}
+}
+
+// TestAddRowInvalidColumn tests that AddRow rejects out-of-range column indices.
+func TestAddRowInvalidColumn(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	if err := solver.AddVars([]float64{0.0, 0.0}, []float64{10.0, 10.0}); err != nil {
+		t.Fatalf("AddVars failed: %v", err)
+	}
+
+	err = solver.AddRow(0.0, 1.0, []int{0, 2}, []float64{1.0, 1.0})
+	if err == nil {
+		t.Fatal("Expected error for out-of-range column index")
+	}
+	if !strings.Contains(err.Error(), "column index 2") {
+		t.Errorf("Error %q does not name the bad index", err)
+	}
+	if solver.NumRow() != 0 {
+		t.Errorf("NumRow = %d, expected 0", solver.NumRow())
+	}
+}
+
+// TestVersion tests that the HiGHS version is reported.
+func TestVersion(t *testing.T) {
+	v := Version()
+	if !regexp.MustCompile(`^\d+\.\d+\.\d+`).MatchString(v) {
+		t.Errorf("Version = %q, expected semver-like string", v)
+	}
+
+	model := Model{ColCosts: []float64{1.0}, ColLower: []float64{0.0}}
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Info.Version != v {
+		t.Errorf("Info.Version = %q, expected %q", sol.Info.Version, v)
+	}
+}
+
+// TestReadModelFileObjectiveRow tests that the MPS objective row becomes
+// ColCosts rather than an extra constraint.
+func TestReadModelFileObjectiveRow(t *testing.T) {
+	const mps = `NAME          TESTLP
+ROWS
+ N  COST
+ L  LIM1
+ G  LIM2
+COLUMNS
+    X0        COST         1.0   LIM1         1.0
+    X0        LIM2         3.0
+    X1        COST         1.0   LIM1         2.0
+    X1        LIM2         2.0
+RHS
+    RHS       LIM1        15.0   LIM2         6.0
+BOUNDS
+ UP BND       X0           4.0
+ LO BND       X1           1.0
+ENDATA
+`
+	filename := filepath.Join(t.TempDir(), "model.mps")
+	if err := os.WriteFile(filename, []byte(mps), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	model, err := ReadModelFile(filename)
+	if err != nil {
+		t.Fatalf("ReadModelFile failed: %v", err)
+	}
+
+	if model.NumConstraints() != 2 {
+		t.Fatalf("NumConstraints = %d, expected 2", model.NumConstraints())
+	}
+	if len(model.ColCosts) != 2 || model.ColCosts[0] != 1.0 || model.ColCosts[1] != 1.0 {
+		t.Errorf("ColCosts = %v, expected [1 1]", model.ColCosts)
+	}
+	if model.ColUpper[0] != 4.0 || model.ColLower[1] != 1.0 {
+		t.Errorf("Unexpected bounds: lower %v, upper %v", model.ColLower, model.ColUpper)
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	// x1 = 1 and 3*x0 + 2 >= 6 gives x0 = 4/3.
+	if !almostEqual(sol.Objective, 7.0/3.0, 0.01) {
+		t.Errorf("Objective = %f, expected 2.333", sol.Objective)
+	}
+}
+
+// TestRowActivity tests that RowActivity matches the full solution.
+func TestRowActivity(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for row, want := range sol.RowValues {
+		got, err := solver.RowActivity(row)
+		if err != nil {
+			t.Fatalf("RowActivity(%d) failed: %v", row, err)
+		}
+		if got != want {
+			t.Errorf("RowActivity(%d) = %f, expected %f", row, got, want)
+		}
+	}
+
+	if _, err := solver.RowActivity(3); err == nil {
+		t.Error("Expected error for out-of-range row")
+	}
+}
+
+// TestPerturbCostsDeterministic tests that equal seeds give equal perturbations.
+func TestPerturbCostsDeterministic(t *testing.T) {
+	base := Model{ColCosts: []float64{1.0, -2.0, 0.0}}
+
+	a, b, c := base, base, base
+	a.PerturbCosts(rand.New(rand.NewPCG(42, 0)), 1e-6)
+	b.PerturbCosts(rand.New(rand.NewPCG(42, 0)), 1e-6)
+	c.PerturbCosts(rand.New(rand.NewPCG(7, 0)), 1e-6)
+
+	for i := range a.ColCosts {
+		if a.ColCosts[i] != b.ColCosts[i] {
+			t.Errorf("ColCosts[%d]: %g != %g for equal seeds", i, a.ColCosts[i], b.ColCosts[i])
+		}
+		if !almostEqual(a.ColCosts[i], base.ColCosts[i], 1e-5) {
+			t.Errorf("ColCosts[%d] = %g, too far from %g", i, a.ColCosts[i], base.ColCosts[i])
+		}
+	}
+	if len(ModelDiff(&a, &c)) == 0 {
+		t.Error("Expected different seeds to give different costs")
+	}
+	if base.ColCosts[0] != 1.0 {
+		t.Error("PerturbCosts modified the shared ColCosts slice")
+	}
+}
+
+// TestInvalidBounds tests that bound setters reject NaN and crossed bounds.
+func TestInvalidBounds(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	if err := solver.AddVars([]float64{0.0, 0.0}, []float64{10.0, 10.0}); err != nil {
+		t.Fatalf("AddVars failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"SetColBounds crossed", func() error { return solver.SetColBounds(0, 5.0, 1.0) }},
+		{"SetColBounds NaN", func() error { return solver.SetColBounds(0, math.NaN(), 1.0) }},
+		{"AddVar crossed", func() error { _, err := solver.AddVar(2.0, 1.0); return err }},
+		{"AddVar NaN", func() error { _, err := solver.AddVar(0.0, math.NaN()); return err }},
+		{"AddVars crossed", func() error { return solver.AddVars([]float64{0.0, 3.0}, []float64{1.0, 2.0}) }},
+		{"AddVars NaN", func() error { return solver.AddVars([]float64{math.NaN()}, []float64{1.0}) }},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+
+	if solver.NumCol() != 2 {
+		t.Errorf("NumCol = %d, expected 2", solver.NumCol())
+	}
+}
+
+// TestSemiContinuousStatus tests classifying semi-continuous variables.
+func TestSemiContinuousStatus(t *testing.T) {
+	// Minimize x + y where x is semi-continuous in {0} ∪ [2, 10]
+	// and y is continuous in [0, 10], subject to x + y >= demand.
+	newModel := func(demand float64) Model {
+		model := Model{
+			ColCosts: []float64{1.0, 1.5},
+			ColLower: []float64{2.0, 0.0},
+			ColUpper: []float64{10.0, 10.0},
+			VarTypes: []VariableType{SemiContinuous, Continuous},
+		}
+		model.AddGeRow([]float64{1.0, 1.0}, demand)
+		return model
+	}
+
+	tests := []struct {
+		demand float64
+		want   string
+	}{
+		{0.0, "off"},
+		{3.0, "on"},
+	}
+	for _, tt := range tests {
+		model := newModel(tt.demand)
+		sol, err := model.Solve(WithOutput(false))
+		if err != nil {
+			t.Fatalf("Solve failed: %v", err)
+		}
+		if !sol.IsOptimal() {
+			t.Fatalf("Expected optimal, got %s", sol.Status)
+		}
+		status := sol.SemiContinuousStatus(&model)
+		if status[0] != tt.want {
+			t.Errorf("demand %g: x is %q (value %f), expected %q", tt.demand, status[0], sol.ColValues[0], tt.want)
+		}
+		if status[1] != "" {
+			t.Errorf("demand %g: continuous y classified as %q", tt.demand, status[1])
+		}
+	}
+}
+
+// TestNumericalWarnings tests that a wide coefficient range is flagged.
+func TestNumericalWarnings(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1e9, 1.0},
+		ColLower: []float64{0.0, 0.0},
+		ColUpper: []float64{10.0, 10.0},
+	}
+	model.AddGeRow([]float64{1e-4, 1.0}, 1.0)
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	// HiGHS's own warnings about the large cost are always reported
+	countRange := func(warnings []string) int {
+		n := 0
+		for _, w := range warnings {
+			if strings.Contains(w, "dynamic range") {
+				n++
+			}
+		}
+		return n
+	}
+	if n := countRange(sol.Warnings); n != 0 {
+		t.Errorf("Expected no dynamic range warnings when disabled, got %v", sol.Warnings)
+	}
+
+	sol, err = model.Solve(WithOutput(false), WithNumericalWarnings(true))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if n := countRange(sol.Warnings); n != 1 {
+		t.Errorf("Expected one dynamic range warning, got %v", sol.Warnings)
+	}
+}
+
+// TestSetNames tests the batch name setters and uniqueness enforcement.
+func TestSetNames(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	if err := solver.AddVars([]float64{0.0, 0.0, 0.0}, []float64{1.0, 1.0, 1.0}); err != nil {
+		t.Fatalf("AddVars failed: %v", err)
+	}
+	if err := solver.AddRow(0.0, 2.0, []int{0, 1, 2}, []float64{1.0, 1.0, 1.0}); err != nil {
+		t.Fatalf("AddRow failed: %v", err)
+	}
+
+	err = solver.SetColNames([]string{"x", "y", "x"}, true)
+	if err == nil || !strings.Contains(err.Error(), `duplicate name "x"`) {
+		t.Errorf("Expected duplicate name error, got %v", err)
+	}
+	if err := solver.SetColNames([]string{"x", "y"}, false); err == nil {
+		t.Error("Expected error for wrong number of names")
+	}
+	if err := solver.SetColNames([]string{"x", "y", "z"}, true); err != nil {
+		t.Errorf("SetColNames failed: %v", err)
+	}
+	if err := solver.SetRowNames([]string{"budget"}, true); err != nil {
+		t.Errorf("SetRowNames failed: %v", err)
+	}
+}
+
+// TestMatrixFormat tests that row-wise and column-wise passing agree.
+func TestMatrixFormat(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0, 0.5},
+		ColLower: []float64{0.0, 1.0, 0.0},
+		ColUpper: []float64{4.0, 1e30, 1.0},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	rowwise, err := model.Solve(WithOutput(false), WithMatrixFormat(MatrixFormatRowwise))
+	if err != nil {
+		t.Fatalf("Solve (rowwise) failed: %v", err)
+	}
+	colwise, err := model.Solve(WithOutput(false), WithMatrixFormat(MatrixFormatColwise))
+	if err != nil {
+		t.Fatalf("Solve (colwise) failed: %v", err)
+	}
+
+	if !rowwise.IsOptimal() || !colwise.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s and %s", rowwise.Status, colwise.Status)
+	}
+	if !almostEqual(rowwise.Objective, colwise.Objective, 1e-9) {
+		t.Errorf("Objective: rowwise %f, colwise %f", rowwise.Objective, colwise.Objective)
+	}
+	for i := range rowwise.ColValues {
+		if !almostEqual(rowwise.ColValues[i], colwise.ColValues[i], 1e-9) {
+			t.Errorf("x%d: rowwise %f, colwise %f", i, rowwise.ColValues[i], colwise.ColValues[i])
+		}
+	}
+}
+
+// TestSolutionMarshalBinary tests that a solution round-trips through
+// the binary encoding.
+func TestSolutionMarshalBinary(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 0.0},
+		ColUpper: []float64{10.0, 10.0},
+	}
+	model.AddDenseRow(1.0, []float64{1.0, 1.0}, 5.0)
+
+	sol, err := model.Solve(WithOutput(false), WithOptionsFingerprint())
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	sol.Warnings = []string{"example warning"}
+	sol.Info.RootRelaxationObjective = 1.5
+	sol.Info.SimplexIterations = 3
+	sol.Pool = []PoolEntry{{Objective: 2, ColValues: []float64{1, 1}}, {Objective: 3}}
+	sol.RowViolations = []float64{0, 1.5}
+	sol.StatusClassifiedFrom = ModelStatusUnboundedOrInfeasible
+	if sol.Info.OptionsFingerprint == "" {
+		t.Error("Info.OptionsFingerprint is empty")
+	}
+
+	data, err := sol.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var decoded Solution
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !reflect.DeepEqual(sol, &decoded) {
+		t.Errorf("Decoded solution differs:\n got %+v\nwant %+v", decoded, *sol)
+	}
+
+	again, err := decoded.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Error("Re-encoded bytes differ from the original encoding")
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-3]); err == nil {
+		t.Error("Expected error for truncated data")
+	}
+	other := append([]byte{solutionEncodingVersion + 1}, data[1:]...)
+	if err := decoded.UnmarshalBinary(other); err == nil {
+		t.Error("Expected error for an unknown encoding version")
+	}
+}
+
+// TestRunIntoNoAllocs tests that repeated RunInto calls reuse the solution buffers.
+func TestRunIntoNoAllocs(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+
+	var sol Solution
+	if err := solver.RunInto(&sol); err != nil {
+		t.Fatalf("RunInto failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 2.5, 0.01) {
+		t.Fatalf("Unexpected solution: %s, objective %f", sol.Status, sol.Objective)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		if err := solver.RunInto(&sol); err != nil {
+			t.Fatalf("RunInto failed: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("RunInto allocated %.0f times per run, expected 0", allocs)
+	}
+
+	// A QP reuses the convexity check made when the Hessian was passed
+	if err := solver.PassHessian(2, []int{0, 1}, []int{0, 1}, []float64{2, 2}); err != nil {
+		t.Fatalf("PassHessian failed: %v", err)
+	}
+	if err := solver.RunInto(&sol); err != nil {
+		t.Fatalf("RunInto failed: %v", err)
+	}
+	allocs = testing.AllocsPerRun(10, func() {
+		if err := solver.RunInto(&sol); err != nil {
+			t.Fatalf("RunInto failed: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("RunInto allocated %.0f times per QP run, expected 0", allocs)
+	}
+}
+
+// newRunIntoSolver builds a small LP directly on a solver.
+func newRunIntoSolver(tb testing.TB) *Solver {
+	solver, err := NewSolver()
+	if err != nil {
+		tb.Fatalf("NewSolver failed: %v", err)
+	}
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		tb.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := solver.AddVars([]float64{0.0, 0.0}, []float64{10.0, 10.0}); err != nil {
+		tb.Fatalf("AddVars failed: %v", err)
+	}
+	if err := solver.SetColCosts([]float64{1.0, 1.0}); err != nil {
+		tb.Fatalf("SetColCosts failed: %v", err)
+	}
+	if err := solver.AddRow(5.0, 15.0, []int{0, 1}, []float64{1.0, 2.0}); err != nil {
+		tb.Fatalf("AddRow failed: %v", err)
+	}
+	return solver
+}
+
+// TestTimeLimitBasis tests that a time-limited LP still returns a basis.
+func TestTimeLimitBasis(t *testing.T) {
+	model := randomModel(3000, 3000, 0.01)
+	model.Maximize = true
+
+	sol, err := model.Solve(
+		WithOutput(false),
+		WithTimeLimit(0.001),
+		WithPresolve("off"),
+		WithStringOption("solver", "simplex"),
+	)
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Status != ModelStatusTimeLimit {
+		t.Fatalf("Status = %s, expected %s", sol.Status, ModelStatusTimeLimit)
+	}
+	if !sol.HasSolution() {
+		t.Fatalf("Expected a solution, got %s", sol.Status)
+	}
+	if len(sol.ColBasis) != 3000 || len(sol.RowBasis) != 3000 {
+		t.Errorf("Basis lengths = (%d, %d), expected (3000, 3000)", len(sol.ColBasis), len(sol.RowBasis))
+	}
+}
+
+// TestBasisWithoutRows tests that a model without constraints still returns a basis.
+func TestBasisWithoutRows(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1.0, -1.0},
+		ColLower: []float64{0.0, 0.0},
+		ColUpper: []float64{1.0, 1.0},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if len(sol.ColBasis) != 2 {
+		t.Fatalf("len(ColBasis) = %d, expected 2", len(sol.ColBasis))
+	}
+	if sol.ColBasis[0] != BasisStatusLower || sol.ColBasis[1] != BasisStatusUpper {
+		t.Errorf("ColBasis = %v, expected [Lower Upper]", sol.ColBasis)
+	}
+}
+
+// TestGroupReport tests per-group binding counts.
+func TestGroupReport(t *testing.T) {
+	// Maximize x + y with two capacity rows and two demand rows.
+	model := Model{
+		Maximize: true,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 0.0},
+	}
+	model.AddLeRow([]float64{1.0, 0.0}, 4.0)  // capacity: binding
+	model.AddLeRow([]float64{0.0, 1.0}, 3.0)  // capacity: binding
+	model.AddGeRow([]float64{1.0, 1.0}, 2.0)  // demand: slack 5
+	model.AddLeRow([]float64{1.0, 1.0}, 10.0) // demand: slack 3
+	model.RowGroups = []string{"capacity", "capacity", "demand", "demand"}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+
+	report := sol.GroupReport(&model)
+	if got := report["capacity"]; got.Rows != 2 || got.Binding != 2 || !almostEqual(got.TotalSlack, 0.0, 1e-6) {
+		t.Errorf("capacity = %+v, expected 2 rows, 2 binding, no slack", got)
+	}
+	if got := report["demand"]; got.Rows != 2 || got.Binding != 0 || !almostEqual(got.TotalSlack, 8.0, 1e-6) {
+		t.Errorf("demand = %+v, expected 2 rows, 0 binding, slack 8", got)
+	}
+}
+
+// TestObjectiveHistory tests recording the objective across incremental edits.
+func TestObjectiveHistory(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+
+	if _, err := solver.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(solver.ObjectiveHistory()) != 0 {
+		t.Fatal("Expected no history before tracking is enabled")
+	}
+
+	solver.SetObjectiveTracking(true)
+	for _, cost := range []float64{1.0, 2.0, 4.0} {
+		if err := solver.SetColCost(1, cost); err != nil {
+			t.Fatalf("SetColCost failed: %v", err)
+		}
+		if _, err := solver.Run(); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	}
+
+	// Minimize x0 + c*x1 subject to x0 + 2*x1 >= 5: for c < 2 use x1 = 2.5,
+	// otherwise use x0 = 5.
+	history := solver.ObjectiveHistory()
+	expected := []float64{2.5, 5.0, 5.0}
+	if len(history) != len(expected) {
+		t.Fatalf("History = %v, expected %v", history, expected)
+	}
+	for i := range expected {
+		if !almostEqual(history[i], expected[i], 0.01) {
+			t.Errorf("History[%d] = %f, expected %f", i, history[i], expected[i])
+		}
+	}
+}
+
+// TestFixedValues tests pinning a variable and re-optimizing the rest.
+func TestFixedValues(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	// With x0 fixed at 3, x0 + 2*x1 >= 5 needs x1 >= 1, its lower bound.
+	sol, err := model.Solve(WithOutput(false), WithFixedValues(map[int]float64{0: 3.0}))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	if !almostEqual(sol.ColValues[0], 3.0, 1e-9) {
+		t.Errorf("x0 = %f, expected 3.0", sol.ColValues[0])
+	}
+	if !almostEqual(sol.ColValues[1], 1.0, 0.01) {
+		t.Errorf("x1 = %f, expected 1.0", sol.ColValues[1])
+	}
+	if !almostEqual(sol.Objective, 7.0, 0.01) {
+		t.Errorf("Objective = %f, expected 7.0", sol.Objective)
+	}
+
+	if _, err := model.Solve(WithOutput(false), WithFixedValues(map[int]float64{0: 5.0})); err == nil {
+		t.Error("Expected error for value outside bounds")
+	}
+	if _, err := model.Solve(WithOutput(false), WithFixedValues(map[int]float64{2: 0.0})); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+}
+
+// TestAddRowsEmptyRow tests a batch of rows containing an empty free row.
+func TestAddRowsEmptyRow(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	solver.SetBoolOption("output_flag", false)
+
+	if err := solver.AddVars([]float64{0.0, 0.0}, []float64{10.0, 10.0}); err != nil {
+		t.Fatalf("AddVars failed: %v", err)
+	}
+	if err := solver.SetColCosts([]float64{1.0, 1.0}); err != nil {
+		t.Fatalf("SetColCosts failed: %v", err)
+	}
+
+	// Rows: x0 + x1 >= 2, an empty free placeholder, x0 - x1 = 1
+	err = solver.AddRows(
+		[]float64{2.0, math.Inf(-1), 1.0},
+		[]float64{math.Inf(1), math.Inf(1), 1.0},
+		[]int{0, 2, 2},
+		[]int{0, 1, 0, 1},
+		[]float64{1.0, 1.0, 1.0, -1.0},
+	)
+	if err != nil {
+		t.Fatalf("AddRows failed: %v", err)
+	}
+	if solver.NumRow() != 3 {
+		t.Fatalf("NumRow = %d, expected 3", solver.NumRow())
+	}
+
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	if !almostEqual(sol.ColValues[0], 1.5, 1e-6) || !almostEqual(sol.ColValues[1], 0.5, 1e-6) {
+		t.Errorf("ColValues = %v, expected [1.5 0.5]", sol.ColValues)
+	}
+	if !almostEqual(sol.RowValues[1], 0.0, 1e-9) {
+		t.Errorf("Empty row activity = %f, expected 0", sol.RowValues[1])
+	}
+
+	// Out-of-range column indices and malformed starts are rejected
+	if err := solver.AddRows([]float64{0.0}, []float64{1.0}, []int{0}, []int{2}, []float64{1.0}); err == nil {
+		t.Error("Expected error for out-of-range column index")
+	}
+	if err := solver.AddRows([]float64{0.0, 0.0}, []float64{1.0, 1.0}, []int{0, 2}, []int{0}, []float64{1.0}); err == nil {
+		t.Error("Expected error for start beyond the index slice")
+	}
+	if solver.NumRow() != 3 {
+		t.Errorf("NumRow = %d after rejected batches, expected 3", solver.NumRow())
+	}
+}
+
+// TestModelEmptyRow tests that a model with an empty row between
+// non-empty rows loads and solves correctly.
+func TestModelEmptyRow(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 0.0},
+		ColUpper: []float64{10.0, 10.0},
+		ConstMatrix: []Nonzero{
+			{0, 0, 1.0},
+			{0, 1, 1.0},
+			{2, 0, 1.0},
+			{2, 1, -1.0},
+		},
+		RowLower: []float64{2.0, math.Inf(-1), 1.0, math.Inf(-1)},
+		RowUpper: []float64{math.Inf(1), math.Inf(1), 1.0, math.Inf(1)},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	if len(sol.RowValues) != 4 {
+		t.Fatalf("len(RowValues) = %d, expected 4", len(sol.RowValues))
+	}
+	if !almostEqual(sol.ColValues[0], 1.5, 1e-6) || !almostEqual(sol.ColValues[1], 0.5, 1e-6) {
+		t.Errorf("ColValues = %v, expected [1.5 0.5]", sol.ColValues)
+	}
+	if !almostEqual(sol.RowValues[2], 1.0, 1e-6) {
+		t.Errorf("RowValues[2] = %f, expected 1.0", sol.RowValues[2])
+	}
+}
+
+// countVars is a generic helper written against the Problem interface.
+func countVars(p Problem) int {
+	return p.NumVars()
+}
+
+// TestProblemInterface tests that Model can be used through Problem.
+func TestProblemInterface(t *testing.T) {
+	model := &Model{
+		ColCosts: []float64{1.0, 2.0},
+		ColLower: []float64{0.0},
+		ConstMatrix: []Nonzero{
+			{0, 2, 3.0},
+			{0, 0, 1.0},
+			{1, 1, 0.0},
+		},
+		RowLower: []float64{1.0},
+		VarTypes: []VariableType{Continuous, Integer},
+	}
+
+	if n := countVars(model); n != 3 {
+		t.Errorf("countVars = %d, expected 3", n)
+	}
+
+	vars := model.Variables()
+	if len(vars) != 3 {
+		t.Fatalf("len(Variables) = %d, expected 3", len(vars))
+	}
+	if vars[0].Lower != 0.0 || !math.IsInf(vars[1].Lower, -1) || vars[1].Type != Integer || vars[2].Cost != 0.0 {
+		t.Errorf("Variables = %+v", vars)
+	}
+
+	cons := model.Constraints()
+	if len(cons) != 2 {
+		t.Fatalf("len(Constraints) = %d, expected 2", len(cons))
+	}
+	if !reflect.DeepEqual(cons[0].Cols, []int{0, 2}) || !reflect.DeepEqual(cons[0].Coeffs, []float64{1.0, 3.0}) {
+		t.Errorf("Constraints[0] = %+v", cons[0])
+	}
+	if len(cons[1].Cols) != 0 || !math.IsInf(cons[1].Lower, -1) {
+		t.Errorf("Constraints[1] = %+v, expected empty free row", cons[1])
+	}
+
+	obj := model.ObjectiveTerms()
+	if !reflect.DeepEqual(obj.Linear, []float64{1.0, 2.0, 0.0}) {
+		t.Errorf("Linear = %v, expected [1 2 0]", obj.Linear)
+	}
+}
+
+// TestMergeDuplicateRows tests collapsing identical rows by intersecting bounds.
+func TestMergeDuplicateRows(t *testing.T) {
+	newModel := func() Model {
+		return Model{
+			ColCosts: []float64{1.0, 2.0},
+			ColLower: []float64{0.0, 0.0},
+			ColUpper: []float64{10.0, 10.0},
+			ConstMatrix: []Nonzero{
+				{0, 0, 1.0}, {0, 1, 1.0},
+				{1, 0, 1.0}, {1, 1, -1.0},
+				{2, 1, 1.0}, {2, 0, 1.0},
+			},
+			RowLower:  []float64{2.0, -1.0, 3.0},
+			RowUpper:  []float64{8.0, 1.0, 12.0},
+			RowGroups: []string{"a", "b", "c"},
+			RowNames:  []string{"low", "diff", "high"},
+		}
+	}
+
+	model := newModel()
+	groups := model.DetectDuplicateRows()
+	if !reflect.DeepEqual(groups, [][]int{{0, 2}}) {
+		t.Fatalf("DetectDuplicateRows = %v, expected [[0 2]]", groups)
+	}
+
+	before, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if err := model.MergeDuplicateRows(); err != nil {
+		t.Fatalf("MergeDuplicateRows failed: %v", err)
+	}
+	if model.NumConstraints() != 2 {
+		t.Fatalf("NumConstraints = %d, expected 2", model.NumConstraints())
+	}
+	if model.RowLower[0] != 3.0 || model.RowUpper[0] != 8.0 {
+		t.Errorf("Merged bounds = [%g, %g], expected [3, 8]", model.RowLower[0], model.RowUpper[0])
+	}
+	if !reflect.DeepEqual(model.RowGroups, []string{"a", "b"}) {
+		t.Errorf("RowGroups = %v, expected [a b]", model.RowGroups)
+	}
+	if !reflect.DeepEqual(model.RowNames, []string{"low", "diff"}) {
+		t.Errorf("RowNames = %q, expected [low diff]", model.RowNames)
+	}
+
+	after, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !almostEqual(before.Objective, after.Objective, 1e-9) {
+		t.Errorf("Objective changed from %f to %f", before.Objective, after.Objective)
+	}
+	for i := range before.ColValues {
+		if !almostEqual(before.ColValues[i], after.ColValues[i], 1e-9) {
+			t.Errorf("ColValues = %v, expected %v", after.ColValues, before.ColValues)
+			break
+		}
+	}
+
+	// A name after a removed row still finds its constraint
+	named := Model{
+		ColCosts:    []float64{1.0, 2.0},
+		ColLower:    []float64{0.0, 0.0},
+		ColUpper:    []float64{10.0, 10.0},
+		ConstMatrix: []Nonzero{{0, 0, 1.0}, {0, 1, 1.0}, {1, 0, 1.0}, {1, 1, 1.0}, {2, 0, 1.0}, {2, 1, -1.0}},
+		RowLower:    []float64{2.0, 3.0, -1.0},
+		RowUpper:    []float64{8.0, 12.0, 1.0},
+		RowNames:    []string{"sum", "sum_again", "diff"},
+	}
+	if err := named.MergeDuplicateRows(); err != nil {
+		t.Fatalf("MergeDuplicateRows failed: %v", err)
+	}
+	if !reflect.DeepEqual(named.RowNames, []string{"sum", "diff"}) {
+		t.Errorf("RowNames = %q, expected [sum diff]", named.RowNames)
+	}
+	sol, err := named.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	activity, err := sol.RowActivityByName(&named, "diff")
+	if err != nil {
+		t.Fatalf("RowActivityByName failed: %v", err)
+	}
+	if expected := sol.ColValues[0] - sol.ColValues[1]; !almostEqual(activity, expected, 1e-9) {
+		t.Errorf("activity of diff = %g, expected %g", activity, expected)
+	}
+
+	// Contradictory bounds are reported and leave the model unchanged
+	model = newModel()
+	model.RowUpper[2] = 1.0
+	if err := model.MergeDuplicateRows(); err == nil || !strings.Contains(err.Error(), "infeasible") {
+		t.Errorf("Expected infeasibility error, got %v", err)
+	}
+	if model.NumConstraints() != 3 || model.RowLower[0] != 2.0 {
+		t.Error("Model modified by failed merge")
+	}
+}
+
+// TestSolutionFilter tests rejecting an incumbent via no-good cuts.
+func TestSolutionFilter(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	solver.SetBoolOption("output_flag", false)
+
+	// max x0 + 2*x1 + 3*x2 s.t. x0 + x1 + x2 <= 2, x binary
+	if err := solver.AddVars([]float64{0, 0, 0}, []float64{1, 1, 1}); err != nil {
+		t.Fatalf("AddVars failed: %v", err)
+	}
+	solver.SetColCosts([]float64{1, 2, 3})
+	solver.SetMaximize(true)
+	solver.SetIntegrality([]VariableType{Integer, Integer, Integer})
+	if err := solver.AddRow(math.Inf(-1), 2, []int{0, 1, 2}, []float64{1, 1, 1}); err != nil {
+		t.Fatalf("AddRow failed: %v", err)
+	}
+
+	// Reject the unconstrained optimum x1 = x2 = 1
+	calls := 0
+	solver.SetSolutionFilter(func(values []float64) bool {
+		calls++
+		return !(math.Round(values[1]) == 1 && math.Round(values[2]) == 1)
+	})
+
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	if !almostEqual(sol.Objective, 4.0, 1e-6) {
+		t.Errorf("Objective = %f, expected 4.0 (x0 = x2 = 1)", sol.Objective)
+	}
+	if calls != 2 {
+		t.Errorf("Filter called %d times, expected 2", calls)
+	}
+	// The cut is deleted again, leaving the loaded model
+	if solver.NumRow() != 1 {
+		t.Errorf("NumRow = %d, expected 1 (cut deleted)", solver.NumRow())
+	}
+	if len(sol.RowValues) != 1 {
+		t.Errorf("len(RowValues) = %d, expected 1", len(sol.RowValues))
+	}
+
+	// Rejecting every solution ends infeasible, with every cut deleted
+	solver.SetSolutionFilter(func([]float64) bool { return false })
+	if sol, err = solver.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsInfeasible() {
+		t.Errorf("Expected infeasible, got %s", sol.Status)
+	}
+	if solver.NumRow() != 1 {
+		t.Errorf("NumRow = %d, expected 1 (cuts deleted)", solver.NumRow())
+	}
+
+	// Non-binary integer variables are not supported
+	solver.SetColBounds(0, 0, 5)
+	if _, err := solver.Run(); err == nil {
+		t.Error("Expected error for non-binary integer variable")
+	}
+}
+
+// TestResetOptions tests that ResetOptions restores option defaults and
+// keeps the model.
+func TestResetOptions(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+
+	if err := solver.SetFloatOption("time_limit", 5.0); err != nil {
+		t.Fatalf("SetFloatOption failed: %v", err)
+	}
+	if err := solver.SetIntOption("threads", 2); err != nil {
+		t.Fatalf("SetIntOption failed: %v", err)
+	}
+	if err := solver.ResetOptions(); err != nil {
+		t.Fatalf("ResetOptions failed: %v", err)
+	}
+
+	if limit, err := solver.GetFloatOption("time_limit"); err != nil || !math.IsInf(limit, 1) {
+		t.Errorf("time_limit = %g (err %v), expected default +Inf", limit, err)
+	}
+	if threads, err := solver.GetIntOption("threads"); err != nil || threads != 0 {
+		t.Errorf("threads = %d (err %v), expected default 0", threads, err)
+	}
+	if solver.NumCol() != 2 || solver.NumRow() != 1 {
+		t.Fatalf("Model lost: %d cols, %d rows", solver.NumCol(), solver.NumRow())
+	}
+
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 2.5, 0.01) {
+		t.Errorf("Unexpected solution: %s, objective %f", sol.Status, sol.Objective)
+	}
+}
+
+// TestProgressReporter tests collecting throttled progress from a MIP solve.
+func TestProgressReporter(t *testing.T) {
+	model := knapsackModel(40, 5)
+
+	var updates []Progress
+	sol, err := model.Solve(WithOutput(false), WithProgressReporter(func(p Progress) {
+		updates = append(updates, p)
+	}))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	if len(updates) < 2 {
+		t.Fatalf("Got %d progress updates, expected at least 2", len(updates))
+	}
+	for i := 1; i < len(updates); i++ {
+		if updates[i].Elapsed < updates[i-1].Elapsed || updates[i].Nodes < updates[i-1].Nodes {
+			t.Errorf("Progress went backwards: %+v after %+v", updates[i], updates[i-1])
+		}
+	}
+	final := updates[len(updates)-1]
+	if !final.Done {
+		t.Error("Last update is not marked Done")
+	}
+	for _, p := range updates[:len(updates)-1] {
+		if p.Done {
+			t.Errorf("Intermediate update marked Done: %+v", p)
+		}
+	}
+	if !almostEqual(final.Incumbent, sol.Objective, 1e-6) {
+		t.Errorf("Final incumbent = %f, expected %f", final.Incumbent, sol.Objective)
+	}
+}
+
+// TestGetColsByRange tests reading back a MIP's columns in one call.
+func TestGetColsByRange(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "mip.lp")
+	content := `Maximize
+ obj: x + 2 y + 3 z
+Subject To
+ c1: x + y + z <= 4
+ c2: x - z >= -1
+Bounds
+ 0 <= x <= 10
+ 0 <= y <= 5
+ z <= 2
+General
+ y z
+End
+`
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	solver.SetBoolOption("output_flag", false)
+	if err := solver.ReadModel(filename); err != nil {
+		t.Fatalf("ReadModel failed: %v", err)
+	}
+
+	cols, err := solver.GetColsByRange(0, solver.NumCol()-1)
+	if err != nil {
+		t.Fatalf("GetColsByRange failed: %v", err)
+	}
+	if !reflect.DeepEqual(cols.Cost, []float64{1, 2, 3}) {
+		t.Errorf("Cost = %v, expected [1 2 3]", cols.Cost)
+	}
+	if !reflect.DeepEqual(cols.Upper, []float64{10, 5, 2}) {
+		t.Errorf("Upper = %v, expected [10 5 2]", cols.Upper)
+	}
+	if !reflect.DeepEqual(cols.Integrality, []VariableType{Continuous, Integer, Integer}) {
+		t.Errorf("Integrality = %v, expected [Continuous Integer Integer]", cols.Integrality)
+	}
+	if !reflect.DeepEqual(cols.Start, []int{0, 2, 3}) || !reflect.DeepEqual(cols.Index, []int{0, 1, 0, 0, 1}) {
+		t.Errorf("Matrix = %v %v, expected [0 2 3] [0 1 0 0 1]", cols.Start, cols.Index)
+	}
+	if !reflect.DeepEqual(cols.Value, []float64{1, 1, 1, 1, -1}) {
+		t.Errorf("Value = %v, expected [1 1 1 1 -1]", cols.Value)
+	}
+
+	sub, err := solver.GetColsByRange(1, 1)
+	if err != nil {
+		t.Fatalf("GetColsByRange failed: %v", err)
+	}
+	if len(sub.Cost) != 1 || sub.Cost[0] != 2 || sub.Integrality[0] != Integer || len(sub.Index) != 1 {
+		t.Errorf("Column 1 = %+v", sub)
+	}
+
+	if _, err := solver.GetColsByRange(2, 3); err == nil {
+		t.Error("Expected error for out-of-range columns")
+	}
+}
+
+// TestSetOptions tests applying a populated SolverOptions struct.
+func TestSetOptions(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	output := false
+	timeLimit, relGap, absGap, primalTol, dualTol := 30.0, 0.02, 0.5, 1e-6, 1e-6
+	threads, seed, maxNodes := 2, 42, 1000
+	presolve, algorithm, parallel := "off", "ipm", "on"
+	err = solver.SetOptions(SolverOptions{
+		Output:                     &output,
+		TimeLimit:                  &timeLimit,
+		Threads:                    &threads,
+		Presolve:                   &presolve,
+		Solver:                     &algorithm,
+		Parallel:                   &parallel,
+		RandomSeed:                 &seed,
+		PrimalFeasibilityTolerance: &primalTol,
+		DualFeasibilityTolerance:   &dualTol,
+		MIPRelGap:                  &relGap,
+		MIPAbsGap:                  &absGap,
+		MIPMaxNodes:                &maxNodes,
+	})
+	if err != nil {
+		t.Fatalf("SetOptions failed: %v", err)
+	}
+
+	if v, err := solver.GetBoolOption("output_flag"); err != nil || v != output {
+		t.Errorf("output_flag = %v (%v), expected %v", v, err, output)
+	}
+	for name, want := range map[string]int{"threads": threads, "random_seed": seed, "mip_max_nodes": maxNodes} {
+		if v, err := solver.GetIntOption(name); err != nil || v != want {
+			t.Errorf("%s = %v (%v), expected %v", name, v, err, want)
+		}
+	}
+	for name, want := range map[string]float64{
+		"time_limit":                   timeLimit,
+		"mip_rel_gap":                  relGap,
+		"mip_abs_gap":                  absGap,
+		"primal_feasibility_tolerance": primalTol,
+		"dual_feasibility_tolerance":   dualTol,
+	} {
+		if v, err := solver.GetFloatOption(name); err != nil || v != want {
+			t.Errorf("%s = %v (%v), expected %v", name, v, err, want)
+		}
+	}
+	for name, want := range map[string]string{"presolve": presolve, "solver": algorithm, "parallel": parallel} {
+		if v, err := solver.GetStringOption(name); err != nil || v != want {
+			t.Errorf("%s = %q (%v), expected %q", name, v, err, want)
+		}
+	}
+
+	// Nil fields leave options unchanged
+	if err := solver.SetOptions(SolverOptions{}); err != nil {
+		t.Fatalf("SetOptions failed: %v", err)
+	}
+	if v, _ := solver.GetFloatOption("time_limit"); v != timeLimit {
+		t.Errorf("time_limit = %v after empty SetOptions, expected %v", v, timeLimit)
+	}
+
+	bad := "sometimes"
+	if err := solver.SetOptions(SolverOptions{Presolve: &bad}); err == nil {
+		t.Error("Expected error for invalid presolve value")
+	}
+}
+
+// TestRunWarnings tests that warnings logged by HiGHS reach Solution.Warnings.
+func TestRunWarnings(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+
+	// Excessively large costs make HiGHS warn but still solve; the
+	// warning is only captured once capture is enabled
+	if err := solver.SetColCosts([]float64{1e12, 1e12}); err != nil {
+		t.Fatalf("SetColCosts failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(sol.Warnings) != 0 {
+		t.Errorf("Warnings = %q without capture, expected none", sol.Warnings)
+	}
+	if err := solver.SetWarningCapture(true); err != nil {
+		t.Fatalf("SetWarningCapture failed: %v", err)
+	}
+	if sol, err = solver.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	if !almostEqual(sol.ColValues[1], 2.5, 1e-6) {
+		t.Errorf("x1 = %f, expected 2.5", sol.ColValues[1])
+	}
+	found := false
+	for _, w := range sol.Warnings {
+		if strings.Contains(w, "excessively large costs") {
+			found = true
+		}
+		if strings.HasPrefix(w, "WARNING") || strings.HasSuffix(w, "\n") {
+			t.Errorf("Warning %q not trimmed", w)
+		}
+	}
+	if !found {
+		t.Errorf("Warnings = %q, expected a large-costs warning", sol.Warnings)
+	}
+
+	// Output stays disabled after the run
+	if v, err := solver.GetBoolOption("output_flag"); err != nil || v {
+		t.Errorf("output_flag = %v (%v), expected false", v, err)
+	}
+
+	// Warnings are captured with console logging off too, which stays off
+	if err := solver.SetBoolOption("log_to_console", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if sol, err = solver.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(sol.Warnings) == 0 {
+		t.Error("Expected warnings with log_to_console off")
+	}
+	for _, name := range []string{"output_flag", "log_to_console"} {
+		if v, err := solver.GetBoolOption(name); err != nil || v {
+			t.Errorf("%s = %v (%v), expected false", name, v, err)
+		}
+	}
+
+	// Warnings do not carry over to the next run
+	solver.SetColCosts([]float64{1.0, 1.0})
+	if sol, err = solver.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(sol.Warnings) != 0 {
+		t.Errorf("Warnings = %q, expected none", sol.Warnings)
+	}
+
+	// While a trace is written to a log file, warnings go to the file
+	model := Model{
+		ColCosts: []float64{1e12, 1e12},
+		ColLower: []float64{0, 0},
+		ColUpper: []float64{10, 10},
+	}
+	model.AddDenseRow(5, []float64{1, 2}, 15)
+	path := filepath.Join(t.TempDir(), "trace.log")
+	if sol, err = model.Solve(WithOutput(false), WithSolveTrace(path)); err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if len(sol.Warnings) != 0 {
+		t.Errorf("Warnings = %q while tracing, expected none", sol.Warnings)
+	}
+	if trace, err := os.ReadFile(path); err != nil || !strings.Contains(string(trace), "excessively large costs") {
+		t.Errorf("trace file lacks the warning (%v)", err)
+	}
+}
+
+// TestSecondaryObjective tests deterministic tie-breaking on a degenerate LP.
+func TestSecondaryObjective(t *testing.T) {
+	// min x + y s.t. x + y >= 2 has a whole edge of optimal solutions
+	model := Model{
+		Offset:   1.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 0.0},
+		ColUpper: []float64{10.0, 10.0},
+	}
+	model.AddGeRow([]float64{1.0, 1.0}, 2.0)
+
+	for _, tc := range []struct {
+		costs []float64
+		want  []float64
+	}{
+		{[]float64{1.0, 0.0}, []float64{0.0, 2.0}},
+		{[]float64{0.0, 1.0}, []float64{2.0, 0.0}},
+	} {
+		for run := 0; run < 3; run++ {
+			sol, err := model.Solve(WithOutput(false), WithSecondaryObjective(tc.costs))
+			if err != nil {
+				t.Fatalf("Solve failed: %v", err)
+			}
+			if !sol.IsOptimal() {
+				t.Fatalf("Expected optimal, got %s", sol.Status)
+			}
+			if !almostEqual(sol.Objective, 3.0, 1e-6) {
+				t.Errorf("Objective = %f, expected primary objective 3.0", sol.Objective)
+			}
+			if !almostEqual(sol.ColValues[0], tc.want[0], 1e-6) || !almostEqual(sol.ColValues[1], tc.want[1], 1e-6) {
+				t.Errorf("Secondary %v run %d: ColValues = %v, expected %v", tc.costs, run, sol.ColValues, tc.want)
+			}
+			if len(sol.RowValues) != 1 {
+				t.Errorf("len(RowValues) = %d, expected 1", len(sol.RowValues))
+			}
+		}
+	}
+
+	if _, err := model.Solve(WithOutput(false), WithSecondaryObjective([]float64{1, 2, 3})); err == nil {
+		t.Error("Expected error for too many secondary costs")
+	}
+}
+
+// TestAddVarIndex tests that AddVar returns consecutive column indices.
+func TestAddVarIndex(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	if err := solver.AddVars([]float64{0, 0}, []float64{1, 1}); err != nil {
+		t.Fatalf("AddVars failed: %v", err)
+	}
+	for want := 2; want < 6; want++ {
+		col, err := solver.AddVar(0, 10)
+		if err != nil {
+			t.Fatalf("AddVar failed: %v", err)
+		}
+		if col != want {
+			t.Errorf("AddVar returned %d, expected %d", col, want)
+		}
+	}
+	if col, err := solver.AddVar(1, 0); err == nil || col != -1 {
+		t.Errorf("AddVar with crossed bounds = (%d, %v), expected (-1, error)", col, err)
+	}
+	if n := solver.NumCol(); n != 6 {
+		t.Errorf("NumCol = %d, expected 6", n)
+	}
+}
+
+// TestSolveTrace tests that WithSolveTrace writes a non-empty trace file.
+func TestSolveTrace(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1, 1},
+		ColLower: []float64{0, 0},
+		ColUpper: []float64{10, 10},
+	}
+	model.AddDenseRow(5, []float64{1, 2}, 15)
+
+	path := filepath.Join(t.TempDir(), "trace.log")
+	sol, err := model.Solve(WithOutput(false), WithSolveTrace(path))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("trace file not created: %v", err)
+	}
+	if info.Size() == 0 {
+		t.Error("trace file is empty")
+	}
+}
+
+// TestColValueMap tests keying solution values by variable name.
+func TestColValueMap(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1, 2, 3, 4},
+		ColLower: []float64{1, 2, 3, 4},
+		ColUpper: []float64{10, 10, 10, 10},
+		ColNames: []string{"x", "y", "x"},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	for _, tc := range []struct {
+		names []string
+		want  map[string]float64
+	}{
+		{[]string{"x", "y", "x"}, map[string]float64{"x": 1, "y": 2, "x_2": 3, "C3": 4}},
+		// A given name equal to a default one keeps it
+		{[]string{"", "C0", "", "C2"}, map[string]float64{"C0_2": 1, "C0": 2, "C2_2": 3, "C2": 4}},
+	} {
+		model.ColNames = tc.names
+		got := sol.ColValueMap(&model)
+		if len(got) != len(tc.want) {
+			t.Fatalf("ColValueMap = %v, expected %v", got, tc.want)
+		}
+		for name, v := range tc.want {
+			if !almostEqual(got[name], v, 1e-9) {
+				t.Errorf("ColValueMap[%q] = %g, expected %g", name, got[name], v)
+			}
+		}
+	}
+}
+
+// TestSetObjectiveSparse tests building the objective from a sparse map.
+func TestSetObjectiveSparse(t *testing.T) {
+	dense := Model{
+		ColCosts: []float64{0, 3, 0, 0, -1},
+		ColLower: []float64{0, 0, 0, 0, 0},
+		ColUpper: []float64{4, 4, 4, 4, 4},
+	}
+	dense.AddDenseRow(2, []float64{1, 1, 1, 1, 1}, 6)
+
+	sparse := Model{
+		ColLower: dense.ColLower,
+		ColUpper: dense.ColUpper,
+	}
+	sparse.AddDenseRow(2, []float64{1, 1, 1, 1, 1}, 6)
+	if err := sparse.SetObjectiveSparse(map[int]float64{1: 3, 4: -1}); err != nil {
+		t.Fatalf("SetObjectiveSparse failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(sparse.ColCosts, dense.ColCosts) {
+		t.Fatalf("ColCosts = %v, expected %v", sparse.ColCosts, dense.ColCosts)
+	}
+
+	want, err := dense.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve dense failed: %v", err)
+	}
+	got, err := sparse.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve sparse failed: %v", err)
+	}
+	if !got.IsOptimal() || !almostEqual(got.Objective, want.Objective, 1e-9) {
+		t.Errorf("sparse objective = %g (%s), dense = %g", got.Objective, got.Status, want.Objective)
+	}
+
+	// Indices beyond the current variables grow the objective
+	if err := sparse.SetObjectiveSparse(map[int]float64{7: 1}); err != nil {
+		t.Fatalf("SetObjectiveSparse failed: %v", err)
+	}
+	if len(sparse.ColCosts) != 8 || sparse.ColCosts[7] != 1 || sparse.ColCosts[1] != 0 {
+		t.Errorf("ColCosts = %v, expected 8 entries with only index 7 set", sparse.ColCosts)
+	}
+
+	// A negative index is an error and leaves the costs alone
+	if err := sparse.SetObjectiveSparse(map[int]float64{-1: 1}); err == nil {
+		t.Error("Expected an error for a negative index")
+	}
+	if len(sparse.ColCosts) != 8 || sparse.ColCosts[7] != 1 {
+		t.Errorf("ColCosts = %v after a rejected call, expected them unchanged", sparse.ColCosts)
+	}
+}
+
+// TestGetResiduals tests the residual diagnostics of an optimal LP.
+func TestGetResiduals(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 2.5, 1e-9) {
+		t.Fatalf("Run = %s with objective %g, expected optimal 2.5", sol.Status, sol.Objective)
+	}
+
+	r, err := solver.GetResiduals()
+	if err != nil {
+		t.Fatalf("GetResiduals failed: %v", err)
+	}
+	for name, v := range map[string]float64{
+		"MaxPrimalInfeasibility":      r.MaxPrimalInfeasibility,
+		"SumPrimalInfeasibilities":    r.SumPrimalInfeasibilities,
+		"MaxDualInfeasibility":        r.MaxDualInfeasibility,
+		"MaxComplementarityViolation": r.MaxComplementarityViolation,
+		"PrimalDualObjectiveError":    r.PrimalDualObjectiveError,
+	} {
+		if v < 0 || v > 1e-7 {
+			t.Errorf("%s = %g, expected within tolerance", name, v)
+		}
+	}
+}
+
+// TestAddSoftConstraint tests that soft constraints are violated when
+// the penalty is cheaper than satisfying them.
+func TestAddSoftConstraint(t *testing.T) {
+	// Minimize 2*x0 + 3*x1 with x0, x1 in [0, 4]. Satisfying x0 + x1 >= 10
+	// is impossible, so the violation of 2 costs 5 per unit; x0 = x1 = 4.
+	// The soft cap x0 <= 1 costs 1 per unit, less than x0 saves over x1.
+	model := Model{
+		ColCosts: []float64{2, 3},
+		ColLower: []float64{0, 0},
+		ColUpper: []float64{4, 4},
+	}
+	for _, c := range []struct {
+		coeffs       []float64
+		sense        Sense
+		rhs, penalty float64
+	}{
+		{[]float64{1, 1}, SenseGreaterEqual, 10, 5},
+		{[]float64{1}, SenseLessEqual, 1, 1},
+		{[]float64{0, 1}, SenseEqual, 4, 100},
+	} {
+		if err := model.AddSoftConstraint(c.coeffs, c.sense, c.rhs, c.penalty); err != nil {
+			t.Fatalf("AddSoftConstraint(%s) failed: %v", c.sense, err)
+		}
+	}
+	if err := model.AddSoftConstraint([]float64{1}, Sense(7), 1, 1); err == nil {
+		t.Error("Expected an error for an unknown sense")
+	}
+
+	if n := model.NumVars(); n != 6 {
+		t.Fatalf("NumVars = %d, expected 6", n)
+	}
+	if n := model.NumConstraints(); n != 3 {
+		t.Fatalf("NumConstraints = %d, expected 3", n)
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	want := []float64{4, 4, 2, 3, 0, 0}
+	for i, v := range want {
+		if !almostEqual(sol.ColValues[i], v, 1e-6) {
+			t.Errorf("ColValues[%d] = %g, expected %g", i, sol.ColValues[i], v)
+		}
+	}
+	// 2*4 + 3*4 + 5*2 + 1*3
+	if !almostEqual(sol.Objective, 33, 1e-6) {
+		t.Errorf("Objective = %g, expected 33", sol.Objective)
+	}
+}
+
+// TestUnboundedDirection tests that the direction of an unbounded LP
+// improves the objective.
+func TestUnboundedDirection(t *testing.T) {
+	// check solves an unbounded LP in the given sense and verifies the
+	// direction it reports.
+	check := func(maximize bool) {
+		// Minimize -x0 - x1 (or maximize x0 + 2*x1) with x0 - x1 <= 1,
+		// x0, x1 >= 0: unbounded as x1 grows.
+		costs := []float64{-1, -1}
+		if maximize {
+			costs = []float64{1, 2}
+		}
+		solver, err := NewSolver()
+		if err != nil {
+			t.Fatalf("NewSolver failed: %v", err)
+		}
+		defer solver.Close()
+		if err := solver.SetBoolOption("output_flag", false); err != nil {
+			t.Fatalf("SetBoolOption failed: %v", err)
+		}
+		if err := solver.PassModel(2, 1, costs, []float64{0, 0}, []float64{math.Inf(1), math.Inf(1)},
+			[]float64{math.Inf(-1)}, []float64{1}, []int{0}, []int{0, 1}, []float64{1, -1},
+			nil, maximize, 0); err != nil {
+			t.Fatalf("PassModel failed: %v", err)
+		}
+
+		sol, err := solver.Run()
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if !sol.IsUnbounded() && sol.Status != ModelStatusUnboundedOrInfeasible {
+			t.Fatalf("Expected unbounded, got %s", sol.Status)
+		}
+
+		dir, err := sol.UnboundedDirection(solver)
+		if err != nil {
+			t.Fatalf("UnboundedDirection failed: %v", err)
+		}
+		slope := costs[0]*dir[0] + costs[1]*dir[1]
+		if maximize {
+			slope = -slope
+		}
+		if slope >= 0 {
+			t.Errorf("maximize=%v: direction %v has slope %g, expected improving", maximize, dir, slope)
+		}
+		if dir[0] < -1e-9 || dir[1] < -1e-9 || dir[0]-dir[1] > 1e-9 {
+			t.Errorf("maximize=%v: direction %v is not a feasible ray", maximize, dir)
+		}
+	}
+	check(false)
+	check(true)
+
+	// Optimal solutions have no unbounded direction
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if _, err := sol.UnboundedDirection(solver); err == nil {
+		t.Error("UnboundedDirection of an optimal solution succeeded, expected error")
+	}
+}
+
+// TestSetColBoundsRange tests changing the bounds of a column range and
+// re-solving.
+func TestSetColBoundsRange(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+
+	// Maximize x0 + 2*x1 + 3*x2 with x0 + x1 + x2 <= 10, x in [0, 4]
+	if err := solver.PassModel(3, 1,
+		[]float64{1, 2, 3}, []float64{0, 0, 0}, []float64{4, 4, 4},
+		[]float64{math.Inf(-1)}, []float64{10},
+		[]int{0}, []int{0, 1, 2}, []float64{1, 1, 1},
+		nil, true, 0); err != nil {
+		t.Fatalf("PassModel failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 22, 1e-9) {
+		t.Fatalf("Objective = %g (%s), expected 22", sol.Objective, sol.Status)
+	}
+
+	// Tighten all bounds to [1, 2]
+	if err := solver.SetColBoundsRange(0, 2, []float64{1, 1, 1}, []float64{2, 2, 2}); err != nil {
+		t.Fatalf("SetColBoundsRange failed: %v", err)
+	}
+	sol, err = solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 12, 1e-9) {
+		t.Errorf("Objective = %g (%s), expected 12", sol.Objective, sol.Status)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		from, to     int
+		lower, upper []float64
+	}{
+		{"length mismatch", 0, 2, []float64{0, 0}, []float64{1, 1, 1}},
+		{"out of range", 1, 3, []float64{0, 0, 0}, []float64{1, 1, 1}},
+		{"reversed", 2, 1, nil, nil},
+		{"crossed", 0, 0, []float64{2}, []float64{1}},
+	} {
+		if err := solver.SetColBoundsRange(tc.from, tc.to, tc.lower, tc.upper); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+// TestSetColCostsBySet tests changing a subset of the objective
+// coefficients between solves.
+func TestSetColCostsBySet(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+
+	// Maximize x0 + 2*x1 + 3*x2 with x0 + x1 + x2 <= 4, x in [0, 4]
+	if err := solver.PassModel(3, 1,
+		[]float64{1, 2, 3}, []float64{0, 0, 0}, []float64{4, 4, 4},
+		[]float64{math.Inf(-1)}, []float64{4},
+		[]int{0}, []int{0, 1, 2}, []float64{1, 1, 1},
+		nil, true, 0); err != nil {
+		t.Fatalf("PassModel failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.ColValues[2], 4, 1e-9) {
+		t.Fatalf("x = %v (%s), expected all of x2", sol.ColValues, sol.Status)
+	}
+
+	// Making x0 the most profitable moves the optimum to it; x1 keeps its cost
+	if err := solver.SetColCostsBySet([]int{2, 0}, []float64{0.5, 5}); err != nil {
+		t.Fatalf("SetColCostsBySet failed: %v", err)
+	}
+	sol, err = solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.ColValues[0], 4, 1e-9) || !almostEqual(sol.Objective, 20, 1e-9) {
+		t.Errorf("x = %v with objective %g (%s), expected all of x0 for 20", sol.ColValues, sol.Objective, sol.Status)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		cols  []int
+		costs []float64
+	}{
+		{"length mismatch", []int{0, 1}, []float64{1}},
+		{"out of range", []int{3}, []float64{1}},
+		{"repeated", []int{1, 1}, []float64{1, 2}},
+	} {
+		if err := solver.SetColCostsBySet(tc.cols, tc.costs); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+// TestSetRowBoundsRange tests changing the bounds of a row range and of a
+// single row between solves.
+func TestSetRowBoundsRange(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+
+	// Maximize x0 + 2*x1 + 3*x2 with x0 + x1 + x2 <= 10, x2 <= 4, x in [0, 4]
+	if err := solver.PassModel(3, 2,
+		[]float64{1, 2, 3}, []float64{0, 0, 0}, []float64{4, 4, 4},
+		[]float64{math.Inf(-1), math.Inf(-1)}, []float64{10, 4},
+		[]int{0, 3}, []int{0, 1, 2, 2}, []float64{1, 1, 1, 1},
+		nil, true, 0); err != nil {
+		t.Fatalf("PassModel failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 22, 1e-9) {
+		t.Fatalf("Objective = %g (%s), expected 22", sol.Objective, sol.Status)
+	}
+
+	// Tighten both rows: x0 + x1 + x2 <= 6 and x2 <= 1 give (1, 4, 1)
+	if err := solver.SetRowBoundsRange(0, 1, []float64{math.Inf(-1), math.Inf(-1)}, []float64{6, 1}); err != nil {
+		t.Fatalf("SetRowBoundsRange failed: %v", err)
+	}
+	sol, err = solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 12, 1e-9) {
+		t.Errorf("Objective = %g (%s), expected 12", sol.Objective, sol.Status)
+	}
+
+	// Fixing x2 = 2 through its row gives (0, 4, 2)
+	if err := solver.SetRowBounds(1, 2, 2); err != nil {
+		t.Fatalf("SetRowBounds failed: %v", err)
+	}
+	sol, err = solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 14, 1e-9) {
+		t.Errorf("Objective = %g (%s), expected 14", sol.Objective, sol.Status)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		from, to     int
+		lower, upper []float64
+	}{
+		{"length mismatch", 0, 1, []float64{0}, []float64{1, 1}},
+		{"out of range", 1, 2, []float64{0, 0}, []float64{1, 1}},
+		{"reversed", 1, 0, nil, nil},
+		{"crossed", 0, 0, []float64{2}, []float64{1}},
+	} {
+		if err := solver.SetRowBoundsRange(tc.from, tc.to, tc.lower, tc.upper); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+	if err := solver.SetRowBounds(0, math.NaN(), 1); err == nil {
+		t.Error("SetRowBounds NaN: expected error")
+	}
+}
+
+// TestPresolve tests that presolve alone removes a redundant constraint,
+// and that a solution of the reduced model postsolves to the optimum.
+func TestPresolve(t *testing.T) {
+	// Row 3 repeats row 0 with a looser bound, so it is redundant, and
+	// removing it is the only reduction presolve can make
+	model := Model{
+		Maximize: true,
+		ColCosts: []float64{2, 3, 4},
+		ColLower: []float64{0, 0, 0},
+		ColUpper: []float64{10, 10, 10},
+		RowLower: []float64{math.Inf(-1), math.Inf(-1), math.Inf(-1), math.Inf(-1)},
+		RowUpper: []float64{12, 12, 12, 100},
+		ConstMatrix: []Nonzero{
+			{Row: 0, Col: 0, Val: 1}, {Row: 0, Col: 1, Val: 2}, {Row: 0, Col: 2, Val: 3},
+			{Row: 1, Col: 0, Val: 3}, {Row: 1, Col: 1, Val: 1}, {Row: 1, Col: 2, Val: 2},
+			{Row: 2, Col: 0, Val: 2}, {Row: 2, Col: 1, Val: 3}, {Row: 2, Col: 2, Val: 1},
+			{Row: 3, Col: 0, Val: 1}, {Row: 3, Col: 1, Val: 2}, {Row: 3, Col: 2, Val: 3},
+		},
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	status, err := solver.Presolve()
+	if err != nil {
+		t.Fatalf("Presolve failed: %v", err)
+	}
+	if status != ModelStatusNotSet {
+		t.Errorf("status = %s, expected the model to remain unsolved", status)
+	}
+	if got := solver.PresolvedNumRow(); got != 3 {
+		t.Errorf("PresolvedNumRow = %d, expected 3", got)
+	}
+	if got := solver.PresolvedNumCol(); got != 3 {
+		t.Errorf("PresolvedNumCol = %d, expected 3", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "presolved.mps")
+	if err := solver.WritePresolvedModel(path); err != nil {
+		t.Fatalf("WritePresolvedModel failed: %v", err)
+	}
+	written, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer written.Close()
+	if err := written.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := written.ReadModel(path); err != nil {
+		t.Fatalf("ReadModel failed: %v", err)
+	}
+	if written.NumRow() != solver.PresolvedNumRow() || written.NumCol() != solver.PresolvedNumCol() {
+		t.Errorf("written model is %dx%d, expected %dx%d",
+			written.NumRow(), written.NumCol(), solver.PresolvedNumRow(), solver.PresolvedNumCol())
+	}
+
+	// Solving the written reduced model and postsolving its solution
+	// reproduces the optimum of the full model
+	want, err := model.Solve(WithOutput(false))
+	if err != nil || !want.IsOptimal() {
+		t.Fatalf("Solve failed: %v, %v", err, want)
+	}
+	reduced, err := written.Run()
+	if err != nil || !reduced.IsOptimal() {
+		t.Fatalf("reduced Run failed: %v, %v", err, reduced)
+	}
+	for _, dual := range []bool{false, true} {
+		var sol *Solution
+		if dual {
+			sol, err = solver.PostsolveDual(reduced.ColValues, reduced.ColDuals, reduced.RowDuals)
+		} else {
+			sol, err = solver.Postsolve(reduced.ColValues)
+		}
+		if err != nil {
+			t.Fatalf("dual %v: postsolve failed: %v", dual, err)
+		}
+		if !sol.Populated || len(sol.ColValues) != model.NumVars() || len(sol.RowValues) != model.NumConstraints() {
+			t.Fatalf("dual %v: postsolved solution has %d columns and %d rows (populated %v)",
+				dual, len(sol.ColValues), len(sol.RowValues), sol.Populated)
+		}
+		if !almostEqual(sol.Objective, want.Objective, 1e-6) {
+			t.Errorf("dual %v: postsolved objective = %v, expected %v", dual, sol.Objective, want.Objective)
+		}
+	}
+
+	if _, err := solver.Postsolve(make([]float64, solver.PresolvedNumCol()+1)); err == nil {
+		t.Error("Expected an error for a solution of the wrong length")
+	}
+}
+
+// TestColumnGeneration tests column generation on a cutting-stock
+// instance against the LP over all cutting patterns.
+func TestColumnGeneration(t *testing.T) {
+	const rollWidth = 10
+	widths := []int{3, 4, 5}
+	demands := []float64{9, 6, 4}
+
+	// enumerate collects every nonempty cutting pattern of a roll.
+	var patterns [][]int
+	var enumerate func(item int, pattern []int, used int)
+	enumerate = func(item int, pattern []int, used int) {
+		if item == len(widths) {
+			if used > 0 {
+				patterns = append(patterns, append([]int(nil), pattern...))
+			}
+			return
+		}
+		for n := 0; used+n*widths[item] <= rollWidth; n++ {
+			pattern[item] = n
+			enumerate(item+1, pattern, used+n*widths[item])
+		}
+	}
+	enumerate(0, make([]int, len(widths)), 0)
+
+	addPattern := func(m *Model, pattern []int) {
+		col := len(m.ColCosts)
+		m.ColCosts = append(m.ColCosts, 1)
+		m.ColLower = append(m.ColLower, 0)
+		m.ColUpper = append(m.ColUpper, math.Inf(1))
+		for item, n := range pattern {
+			if n > 0 {
+				m.ConstMatrix = append(m.ConstMatrix, Nonzero{Row: item, Col: col, Val: float64(n)})
+			}
+		}
+	}
+	newMaster := func() *Model {
+		m := &Model{RowUpper: []float64{math.Inf(1), math.Inf(1), math.Inf(1)}}
+		m.RowLower = append(m.RowLower, demands...)
+		return m
+	}
+
+	full := newMaster()
+	for _, p := range patterns {
+		addPattern(full, p)
+	}
+	want, err := full.Solve(WithOutput(false))
+	if err != nil || !want.IsOptimal() {
+		t.Fatalf("full pattern LP failed: %v (%v)", err, want)
+	}
+
+	// Start from one pattern per item and price by enumeration
+	master := newMaster()
+	for item, w := range widths {
+		p := make([]int, len(widths))
+		p[item] = rollWidth / w
+		addPattern(master, p)
+	}
+	iterations := 0
+	pricing := func(duals []float64) (Column, bool) {
+		iterations++
+		best, bestValue := -1, 1+1e-9
+		for i, p := range patterns {
+			value := 0.0
+			for item, n := range p {
+				value += duals[item] * float64(n)
+			}
+			if value > bestValue {
+				best, bestValue = i, value
+			}
+		}
+		if best < 0 {
+			return Column{}, false
+		}
+		col := Column{Cost: 1, Lower: 0, Upper: math.Inf(1)}
+		for item, n := range patterns[best] {
+			if n > 0 {
+				col.Rows = append(col.Rows, item)
+				col.Values = append(col.Values, float64(n))
+			}
+		}
+		return col, true
+	}
+
+	sol, err := ColumnGeneration(master, pricing, WithOutput(false))
+	if err != nil {
+		t.Fatalf("ColumnGeneration failed: %v", err)
+	}
+	if !almostEqual(sol.Objective, want.Objective, 1e-6) {
+		t.Errorf("Objective = %g, expected %g", sol.Objective, want.Objective)
+	}
+	if iterations < 2 {
+		t.Errorf("pricing called %d times, expected columns to be generated", iterations)
+	}
+	if n := master.NumVars(); n != len(sol.ColValues) || n <= len(widths) {
+		t.Errorf("master has %d variables, solution %d", n, len(sol.ColValues))
+	}
+
+	// A non-improving column is rejected
+	_, err = ColumnGeneration(master, func([]float64) (Column, bool) {
+		return Column{Cost: 1, Upper: math.Inf(1), Rows: []int{0}, Values: []float64{1}}, true
+	}, WithOutput(false))
+	if err == nil || !strings.Contains(err.Error(), "reduced cost") {
+		t.Errorf("non-improving column: err = %v, expected reduced cost error", err)
+	}
+}
+
+// TestDeleteCols tests deleting columns by set and by range.
+func TestDeleteCols(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+
+	// Five variables x_i in [i, 10] with cost i+1 and sum(x) >= 15
+	for i := 0; i < 5; i++ {
+		if _, err := solver.AddVar(float64(i), 10); err != nil {
+			t.Fatalf("AddVar failed: %v", err)
+		}
+	}
+	if err := solver.SetColCosts([]float64{1, 2, 3, 4, 5}); err != nil {
+		t.Fatalf("SetColCosts failed: %v", err)
+	}
+	if err := solver.AddRow(15, math.Inf(1), []int{0, 1, 2, 3, 4}, []float64{1, 1, 1, 1, 1}); err != nil {
+		t.Fatalf("AddRow failed: %v", err)
+	}
+
+	if err := solver.DeleteColsBySet([]int{3, 1}); err != nil {
+		t.Fatalf("DeleteColsBySet failed: %v", err)
+	}
+	if n := solver.NumCol(); n != 3 {
+		t.Fatalf("NumCol = %d, expected 3", n)
+	}
+
+	// Former columns 0, 2, 4 are now 0, 1, 2: x0 = 15 - 2 - 4 = 9
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	want := []float64{9, 2, 4}
+	for i, v := range want {
+		if !almostEqual(sol.ColValues[i], v, 1e-9) {
+			t.Errorf("ColValues[%d] = %g, expected %g", i, sol.ColValues[i], v)
+		}
+	}
+	if !almostEqual(sol.Objective, 9+6+20, 1e-9) {
+		t.Errorf("Objective = %g, expected 35", sol.Objective)
+	}
+
+	if err := solver.DeleteColsBySet([]int{0, 0}); err == nil {
+		t.Error("DeleteColsBySet with a repeated column succeeded, expected error")
+	}
+	if err := solver.DeleteColsByRange(1, 3); err == nil {
+		t.Error("DeleteColsByRange out of range succeeded, expected error")
+	}
+	if err := solver.DeleteColsByRange(1, 2); err != nil {
+		t.Fatalf("DeleteColsByRange failed: %v", err)
+	}
+	if n := solver.NumCol(); n != 1 {
+		t.Errorf("NumCol = %d, expected 1", n)
+	}
+}
+
+// TestActiveSystem tests the binding rows and their coefficients at the
+// TestLP optimum.
+func TestActiveSystem(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	// Rows 1 and 2 bind at x = (0.5, 2.25); no variable is at a bound
+	active := sol.ActiveSystem(&model, 1e-7)
+	if !reflect.DeepEqual(active.Rows, []int{1, 2}) || len(active.Cols) != 0 {
+		t.Errorf("ActiveSystem rows %v, cols %v, expected [1 2] and none", active.Rows, active.Cols)
+	}
+	expected := []Nonzero{{0, 0, 1.0}, {0, 1, 2.0}, {1, 0, 3.0}, {1, 1, 2.0}}
+	if !reflect.DeepEqual(active.Matrix, expected) {
+		t.Errorf("ActiveSystem matrix = %v, expected %v", active.Matrix, expected)
+	}
+}
+
+// TestActiveSystemBounds tests that active variable bounds are appended
+// as unit rows.
+func TestActiveSystemBounds(t *testing.T) {
+	model := Model{
+		ColLower: []float64{0, 1, math.Inf(-1)},
+		ColUpper: []float64{4, 1, 3},
+		RowLower: []float64{2},
+		RowUpper: []float64{math.Inf(1)},
+	}
+	model.ConstMatrix = []Nonzero{{0, 0, 1}, {0, 2, 1}}
+	sol := &Solution{ColValues: []float64{0, 1, 3}, RowValues: []float64{3}}
+
+	active := sol.ActiveSystem(&model, 1e-9)
+	if len(active.Rows) != 0 || !reflect.DeepEqual(active.Cols, []int{0, 1, 2}) {
+		t.Fatalf("ActiveSystem rows %v, cols %v, expected none and [0 1 2]", active.Rows, active.Cols)
+	}
+	want := []Nonzero{{0, 0, 1}, {1, 1, 1}, {2, 2, 1}}
+	if !reflect.DeepEqual(active.Matrix, want) {
+		t.Errorf("ActiveSystem matrix = %v, expected %v", active.Matrix, want)
+	}
+}
+
+// TestClean tests snapping near-zero solution values to zero.
+func TestClean(t *testing.T) {
+	sol := &Solution{
+		ColValues: []float64{1e-13, -2e-12, 0.5, -3},
+		RowValues: []float64{1e-10},
+		ColDuals:  []float64{-1e-14, 1e-6},
+		Objective: 1e-13,
+	}
+	sol.Clean(1e-9)
+
+	if want := []float64{0, 0, 0.5, -3}; !reflect.DeepEqual(sol.ColValues, want) {
+		t.Errorf("ColValues = %v, expected %v", sol.ColValues, want)
+	}
+	if sol.RowValues[0] != 0 || sol.ColDuals[0] != 0 || sol.ColDuals[1] != 1e-6 {
+		t.Errorf("RowValues = %v, ColDuals = %v, expected [0] and [0 1e-06]", sol.RowValues, sol.ColDuals)
+	}
+	if sol.Objective != 1e-13 {
+		t.Errorf("Objective = %g, expected unchanged", sol.Objective)
+	}
+
+	// x0 sits at its lower bound of -1e-12
+	model := Model{
+		ColCosts: []float64{2, 1},
+		ColLower: []float64{-1e-12, 0},
+		ColUpper: []float64{1, 1},
+	}
+	model.AddDenseRow(0.5, []float64{1, 1}, math.Inf(1))
+
+	solved, err := model.Solve(WithOutput(false), WithZeroThreshold(1e-9))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if solved.ColValues[0] != 0 || !almostEqual(solved.ColValues[1], 0.5, 1e-9) {
+		t.Errorf("ColValues = %v, expected [0 0.5]", solved.ColValues)
+	}
+}
+
+// TestDeleteRows tests deleting rows by set and by range.
+func TestDeleteRows(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+
+	// Minimize x0 + x1 with x0 >= 2, x0 + x1 >= 10, x1 >= 3
+	if err := solver.AddVars([]float64{0, 0}, []float64{100, 100}); err != nil {
+		t.Fatalf("AddVars failed: %v", err)
+	}
+	if err := solver.SetColCosts([]float64{1, 1}); err != nil {
+		t.Fatalf("SetColCosts failed: %v", err)
+	}
+	if err := solver.AddRows([]float64{2, 10, 3}, []float64{math.Inf(1), math.Inf(1), math.Inf(1)},
+		[]int{0, 1, 3}, []int{0, 0, 1, 1}, []float64{1, 1, 1, 1}); err != nil {
+		t.Fatalf("AddRows failed: %v", err)
+	}
+
+	if err := solver.DeleteRowsBySet([]int{1}); err != nil {
+		t.Fatalf("DeleteRowsBySet failed: %v", err)
+	}
+	if n := solver.NumRow(); n != 2 {
+		t.Fatalf("NumRow = %d, expected 2", n)
+	}
+
+	// Without the middle row, both remaining rows bind: x = (2, 3)
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, 5, 1e-9) {
+		t.Fatalf("Objective = %g (%s), expected 5", sol.Objective, sol.Status)
+	}
+	if !almostEqual(sol.RowValues[0], 2, 1e-9) || !almostEqual(sol.RowValues[1], 3, 1e-9) {
+		t.Errorf("RowValues = %v, expected [2 3]", sol.RowValues)
+	}
+
+	if err := solver.DeleteRowsBySet([]int{2}); err == nil {
+		t.Error("DeleteRowsBySet out of range succeeded, expected error")
+	}
+	if err := solver.DeleteRowsByRange(-1, 0); err == nil {
+		t.Error("DeleteRowsByRange out of range succeeded, expected error")
+	}
+	if err := solver.DeleteRowsByRange(0, 1); err != nil {
+		t.Fatalf("DeleteRowsByRange failed: %v", err)
+	}
+	if n := solver.NumRow(); n != 0 {
+		t.Errorf("NumRow = %d, expected 0", n)
+	}
+}
+
+// TestSetBasis tests warm-starting a re-solve from a previous basis.
+func TestSetBasis(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+	// Without presolve the cold solve needs simplex iterations
+	if err := solver.SetStringOption("presolve", "off"); err != nil {
+		t.Fatalf("SetStringOption failed: %v", err)
+	}
+
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	if cold, err := solver.GetIntInfo("simplex_iteration_count"); err != nil || cold == 0 {
+		t.Fatalf("cold simplex_iteration_count = %d (%v), expected iterations", cold, err)
+	}
+
+	if err := solver.ClearSolver(); err != nil {
+		t.Fatalf("ClearSolver failed: %v", err)
+	}
+	if err := solver.SetBasis(sol.ColBasis, sol.RowBasis); err != nil {
+		t.Fatalf("SetBasis failed: %v", err)
+	}
+	warm, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !warm.IsOptimal() || !almostEqual(warm.Objective, sol.Objective, 1e-9) {
+		t.Fatalf("warm Objective = %g (%s), expected %g", warm.Objective, warm.Status, sol.Objective)
+	}
+	iterations, err := solver.GetIntInfo("simplex_iteration_count")
+	if err != nil {
+		t.Fatalf("GetIntInfo failed: %v", err)
+	}
+	if iterations != 0 {
+		t.Errorf("simplex_iteration_count = %d after warm start, expected 0", iterations)
+	}
+
+	lower, basic := BasisStatusLower, BasisStatusBasic
+	for _, tc := range []struct {
+		name               string
+		colBasis, rowBasis []BasisStatus
+		want               string
+	}{
+		{"short colBasis", []BasisStatus{basic}, []BasisStatus{lower}, "colBasis has length 1"},
+		{"long rowBasis", []BasisStatus{basic, lower}, []BasisStatus{lower, lower}, "rowBasis has length 2"},
+		{"no basic", []BasisStatus{lower, lower}, []BasisStatus{lower}, "0 basic variables"},
+		{"too many basic", []BasisStatus{basic, basic}, []BasisStatus{lower}, "2 basic variables"},
+		{"invalid status", []BasisStatus{basic, BasisStatus(99)}, []BasisStatus{lower}, "invalid basis status"},
+	} {
+		err := solver.SetBasis(tc.colBasis, tc.rowBasis)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, expected %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+// TestWithInitialBasis tests warm-starting Model.Solve from the basis of
+// a previous solution.
+func TestWithInitialBasis(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1, 1, 1},
+		ColLower: []float64{0, 0, 0},
+		ColUpper: []float64{10, 10, 10},
+	}
+	model.AddDenseRow(5, []float64{1, 2, 1}, 15)
+	model.AddDenseRow(2, []float64{1, 0, 3}, math.Inf(1))
+
+	first, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !first.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", first.Status)
+	}
+
+	model.ColCosts[0] = 1.1
+	sol, err := model.Solve(WithOutput(false), WithInitialBasis(first.ColBasis, first.RowBasis))
+	if err != nil {
+		t.Fatalf("Solve with initial basis failed: %v", err)
+	}
+	want, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() || !almostEqual(sol.Objective, want.Objective, 1e-9) {
+		t.Errorf("Objective = %g (%s), expected %g", sol.Objective, sol.Status, want.Objective)
+	}
+
+	// A basis for different dimensions is an error
+	model.AddDenseRow(0, []float64{1, 1, 1}, 20)
+	if _, err := model.Solve(WithOutput(false), WithInitialBasis(first.ColBasis, first.RowBasis)); err == nil {
+		t.Error("Solve with a stale basis succeeded, expected error")
+	}
+}
+
+// TestOptionsFingerprint tests that the options fingerprint depends only
+// on the option values.
+func TestOptionsFingerprint(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1, 1},
+		ColLower: []float64{0, 0},
+		ColUpper: []float64{10, 10},
+	}
+	model.AddDenseRow(5, []float64{1, 2}, 15)
+
+	solve := func(opts ...SolveOption) string {
+		t.Helper()
+		sol, err := model.Solve(append([]SolveOption{WithOutput(false), WithOptionsFingerprint()}, opts...)...)
+		if err != nil {
+			t.Fatalf("Solve failed: %v", err)
+		}
+		return sol.Info.OptionsFingerprint
+	}
+
+	a := solve(WithTimeLimit(60))
+	b := solve(WithTimeLimit(60))
+	if a == "" || a != b {
+		t.Errorf("identical options gave fingerprints %q and %q", a, b)
+	}
+	if c := solve(WithTimeLimit(30)); c == a {
+		t.Errorf("changed time limit kept fingerprint %q", c)
+	}
+	if d := solve(WithTimeLimit(60), WithThreads(1)); d == a {
+		t.Errorf("changed threads kept fingerprint %q", d)
+	}
+
+	// A model without variables, solved without HiGHS, still records it
+	empty := Model{}
+	sol, err := empty.Solve(WithOutput(false), WithOptionsFingerprint(), WithTimeLimit(60))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Info.OptionsFingerprint != a {
+		t.Errorf("empty model fingerprint %q, expected %q", sol.Info.OptionsFingerprint, a)
+	}
+
+	// The fingerprint is opt-in
+	if sol, err = model.Solve(WithOutput(false)); err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Info.OptionsFingerprint != "" {
+		t.Errorf("fingerprint %q without WithOptionsFingerprint, expected none", sol.Info.OptionsFingerprint)
+	}
+}
+
+// TestOptionType tests looking up the type of an option by name.
+func TestOptionType(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+
+	for name, want := range map[string]OptionType{
+		"output_flag": OptionTypeBool,
+		"threads":     OptionTypeInt,
+		"time_limit":  OptionTypeDouble,
+		"solver":      OptionTypeString,
+	} {
+		if got, err := solver.OptionType(name); err != nil || got != want {
+			t.Errorf("OptionType(%q) = %s (err %v), expected %s", name, got, err, want)
+		}
+	}
+	if _, err := solver.OptionType("no_such_option"); err == nil {
+		t.Error("Expected an error for an unknown option")
+	}
+}
+
+// TestOptionInfo tests reading the current value, default, and range of
+// options.
+func TestOptionInfo(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetFloatOption("mip_rel_gap", 0.05); err != nil {
+		t.Fatalf("SetFloatOption failed: %v", err)
+	}
+
+	current, def, lo, hi, err := solver.FloatOptionInfo("mip_rel_gap")
+	if err != nil {
+		t.Fatalf("FloatOptionInfo failed: %v", err)
+	}
+	if current != 0.05 {
+		t.Errorf("mip_rel_gap current = %v, expected 0.05", current)
+	}
+	if def < 0 || def > 0.01 {
+		t.Errorf("mip_rel_gap default = %v, expected a small nonnegative number", def)
+	}
+	if lo > def || def > hi {
+		t.Errorf("mip_rel_gap range [%v, %v] does not contain the default %v", lo, hi, def)
+	}
+
+	if err := solver.SetIntOption("threads", 2); err != nil {
+		t.Fatalf("SetIntOption failed: %v", err)
+	}
+	icurrent, idef, ilo, ihi, err := solver.IntOptionInfo("threads")
+	if err != nil {
+		t.Fatalf("IntOptionInfo failed: %v", err)
+	}
+	if icurrent != 2 || idef != 0 || ilo > idef || idef > ihi {
+		t.Errorf("threads = %d, default %d, range [%d, %d], expected 2, 0, and a range containing 0",
+			icurrent, idef, ilo, ihi)
+	}
+
+	if _, _, _, _, err := solver.FloatOptionInfo("threads"); err == nil {
+		t.Error("Expected an error for an integer option read as double")
+	}
+	if _, _, _, _, err := solver.IntOptionInfo("no_such_option"); err == nil {
+		t.Error("Expected an error for an unknown option")
+	}
+}
+
+// TestReadWriteOptions tests that options written by one solver are read
+// back by another, in full and as only the modified options.
+func TestReadWriteOptions(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := solver.SetFloatOption("time_limit", 42.5); err != nil {
+		t.Fatalf("SetFloatOption failed: %v", err)
+	}
+	if err := solver.SetIntOption("threads", 3); err != nil {
+		t.Fatalf("SetIntOption failed: %v", err)
+	}
+	if err := solver.SetStringOption("solver", "ipm"); err != nil {
+		t.Fatalf("SetStringOption failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	full := filepath.Join(dir, "full.txt")
+	modified := filepath.Join(dir, "modified.txt")
+	if err := solver.WriteOptions(full); err != nil {
+		t.Fatalf("WriteOptions failed: %v", err)
+	}
+	if err := solver.WriteModifiedOptions(modified); err != nil {
+		t.Fatalf("WriteModifiedOptions failed: %v", err)
+	}
+	fullInfo, err := os.Stat(full)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	modifiedInfo, err := os.Stat(modified)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if modifiedInfo.Size() >= fullInfo.Size() {
+		t.Errorf("modified options (%d bytes) not smaller than all options (%d bytes)", modifiedInfo.Size(), fullInfo.Size())
+	}
+
+	for _, path := range []string{full, modified} {
+		fresh, err := NewSolver()
+		if err != nil {
+			t.Fatalf("NewSolver failed: %v", err)
+		}
+		defer fresh.Close()
+		if err := fresh.SetBoolOption("output_flag", false); err != nil {
+			t.Fatalf("SetBoolOption failed: %v", err)
+		}
+		if err := fresh.ReadOptions(path); err != nil {
+			t.Fatalf("%s: ReadOptions failed: %v", filepath.Base(path), err)
+		}
+		timeLimit, err1 := fresh.GetFloatOption("time_limit")
+		threads, err2 := fresh.GetIntOption("threads")
+		solverName, err3 := fresh.GetStringOption("solver")
+		if err := errors.Join(err1, err2, err3); err != nil {
+			t.Fatalf("%s: reading the options back failed: %v", filepath.Base(path), err)
+		}
+		if timeLimit != 42.5 || threads != 3 || solverName != "ipm" {
+			t.Errorf("%s: read time_limit %v, threads %d, solver %q, expected 42.5, 3, \"ipm\"",
+				filepath.Base(path), timeLimit, threads, solverName)
+		}
+	}
+
+	if err := solver.ReadOptions(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("Expected an error for a missing options file")
+	}
+}
+
+// TestRanging tests cost ranging on the TestLP model and that MIPs are
+// rejected.
+func TestRanging(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if _, err := solver.Ranging(); err == nil {
+		t.Error("Ranging before Run succeeded, expected error")
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	r, err := solver.Ranging()
+	if err != nil {
+		t.Fatalf("Ranging failed: %v", err)
+	}
+	if len(r.ColCostUp.Value) != 2 || len(r.RowBoundUp.Value) != 3 {
+		t.Fatalf("Ranging lengths %d and %d, expected 2 and 3", len(r.ColCostUp.Value), len(r.RowBoundUp.Value))
+	}
+	for col, cost := range model.ColCosts {
+		down, up := r.ColCostDown.Value[col], r.ColCostUp.Value[col]
+		if math.IsInf(down, 0) || math.IsInf(up, 0) || down > cost || up < cost {
+			t.Errorf("cost range of x%d = [%g, %g], expected finite and containing %g", col, down, up, cost)
+		}
+	}
+
+	if err := solver.SetColIntegrality(0, Integer); err != nil {
+		t.Fatalf("SetColIntegrality failed: %v", err)
+	}
+	if _, err := solver.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if _, err := solver.Ranging(); err == nil {
+		t.Error("Ranging of a MIP succeeded, expected error")
+	}
+}
+
+// TestGetModelStatus tests the model status accessors before and after
+// solving the TestLP model.
+func TestGetModelStatus(t *testing.T) {
+	model := Model{
+		Offset:   3.0,
+		ColCosts: []float64{1.0, 1.0},
+		ColLower: []float64{0.0, 1.0},
+		ColUpper: []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{
+			{0, 1, 1.0},
+			{1, 0, 1.0},
+			{1, 1, 2.0},
+			{2, 0, 3.0},
+			{2, 1, 2.0},
+		},
+		RowLower: []float64{-1e30, 5.0, 6.0},
+		RowUpper: []float64{7.0, 15.0, 1e30},
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if status := solver.GetModelStatus(); status != ModelStatusNotSet {
+		t.Errorf("GetModelStatus before Run = %s, expected NotSet", status)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	if status := solver.GetModelStatus(); status != ModelStatusOptimal {
+		t.Errorf("GetModelStatus = %s, expected Optimal", status)
+	}
+	if status := solver.GetScaledModelStatus(); status != ModelStatusOptimal {
+		t.Errorf("GetScaledModelStatus = %s, expected Optimal", status)
+	}
+}
+
+// TestDualRay tests extracting a dual ray from the TestInfeasible model.
+func TestDualRay(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1.0},
+		ColLower: []float64{0.0},
+		ColUpper: []float64{10.0},
+	}
+	model.AddDenseRow(5.0, []float64{1.0}, math.Inf(1))
+	model.AddDenseRow(math.Inf(-1), []float64{1.0}, 3.0)
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !sol.IsInfeasible() {
+		t.Fatalf("Expected infeasible, got %s", sol.Status)
+	}
+
+	ray, ok, err := solver.DualRay()
+	if err != nil {
+		t.Fatalf("DualRay failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("DualRay reported no ray for an infeasible LP")
+	}
+	if len(ray) != 2 {
+		t.Fatalf("len(DualRay) = %d, expected 2", len(ray))
+	}
+	// Both rows take part in the certificate, with opposite signs
+	if ray[0] == 0 || ray[1] == 0 || ray[0]*ray[1] > 0 {
+		t.Errorf("DualRay = %v, expected nonzero multipliers of opposite sign", ray)
+	}
+
+	if _, ok, err := solver.PrimalRay(); err != nil || ok {
+		t.Errorf("PrimalRay = (%v, %v), expected no ray for an infeasible LP", ok, err)
+	}
+}
+
+// TestWithWarmStart tests warm-starting a perturbed LP from the previous
+// solution, and a MIP from its previous solution values.
+func TestWithWarmStart(t *testing.T) {
+	model := randomModel(30, 40, 0.3)
+	model.Maximize = true
+	opts := []SolveOption{WithOutput(false), WithPresolve("off")}
+
+	first, err := model.Solve(opts...)
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !first.IsOptimal() || first.Info.SimplexIterations == 0 {
+		t.Fatalf("cold solve: %s with %d iterations, expected optimal with iterations",
+			first.Status, first.Info.SimplexIterations)
+	}
+
+	model.ColCosts[0] *= 1.01
+	cold, err := model.Solve(opts...)
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	warm, err := model.Solve(append(opts, WithWarmStart(first))...)
+	if err != nil {
+		t.Fatalf("warm Solve failed: %v", err)
+	}
+	if !warm.IsOptimal() || !almostEqual(warm.Objective, cold.Objective, 1e-6) {
+		t.Fatalf("warm Objective = %g (%s), expected %g", warm.Objective, warm.Status, cold.Objective)
+	}
+	if warm.Info.SimplexIterations >= cold.Info.SimplexIterations {
+		t.Errorf("warm start took %d iterations, cold %d", warm.Info.SimplexIterations, cold.Info.SimplexIterations)
+	}
+
+	mip := knapsackModel(20, 2)
+	mipSol, err := mip.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve MIP failed: %v", err)
+	}
+	again, err := mip.Solve(WithOutput(false), WithWarmStart(mipSol))
+	if err != nil {
+		t.Fatalf("warm Solve MIP failed: %v", err)
+	}
+	if !almostEqual(again.Objective, mipSol.Objective, 1e-6) {
+		t.Errorf("warm MIP Objective = %g, expected %g", again.Objective, mipSol.Objective)
+	}
+
+	short := &Solution{ColBasis: first.ColBasis[:1], RowBasis: first.RowBasis}
+	if _, err := model.Solve(append(opts, WithWarmStart(short))...); err == nil {
+		t.Error("Solve with a mismatched warm start succeeded, expected error")
+	}
+}
+
+// TestRowActivityByName tests looking up constraint activities by name.
+func TestRowActivityByName(t *testing.T) {
+	model := Model{
+		ColCosts: []float64{1, 1},
+		ColLower: []float64{0, 0},
+		ColUpper: []float64{10, 10},
+		RowNames: []string{"demand", ""},
+	}
+	model.AddDenseRow(5, []float64{1, 2}, 15)
+	model.AddDenseRow(1, []float64{1, 0}, 10)
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+
+	for name, row := range map[string]int{"demand": 0, "R1": 1} {
+		got, err := sol.RowActivityByName(&model, name)
+		if err != nil {
+			t.Fatalf("RowActivityByName(%q) failed: %v", name, err)
+		}
+		if got != sol.RowValues[row] {
+			t.Errorf("RowActivityByName(%q) = %g, expected %g", name, got, sol.RowValues[row])
+		}
+	}
+	if _, err := sol.RowActivityByName(&model, "supply"); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("RowActivityByName(\"supply\") error = %v, expected ErrUnknownName", err)
+	}
+
+	// A row named like the default of an unnamed row keeps its name
+	model.RowNames = []string{"", "R0"}
+	for name, row := range map[string]int{"R0": 1, "R0_2": 0} {
+		if got, err := sol.RowActivityByName(&model, name); err != nil || got != sol.RowValues[row] {
+			t.Errorf("RowActivityByName(%q) = (%g, %v), expected %g", name, got, err, sol.RowValues[row])
+		}
+	}
+}
+
+// TestObjectiveAccessors tests reading back the objective sense and value.
+func TestObjectiveAccessors(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+
+	if maximize, err := solver.ObjectiveSense(); err != nil || maximize {
+		t.Errorf("ObjectiveSense = (%v, %v), expected minimize", maximize, err)
+	}
+	if err := solver.SetMaximize(true); err != nil {
+		t.Fatalf("SetMaximize failed: %v", err)
+	}
+	if maximize, err := solver.ObjectiveSense(); err != nil || !maximize {
+		t.Errorf("ObjectiveSense = (%v, %v), expected maximize", maximize, err)
+	}
+
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	// Maximize x0 + x1 with x0 + 2*x1 <= 15 and x in [0, 10]: 12.5
+	if got := solver.ObjectiveValue(); got != sol.Objective || !almostEqual(got, 12.5, 1e-9) {
+		t.Errorf("ObjectiveValue = %g, expected %g", got, sol.Objective)
+	}
+}
+
+// TestAddPiecewiseLinear tests that the piecewise-linear variable tracks
+// the interpolation of x² in both objective directions.
+func TestAddPiecewiseLinear(t *testing.T) {
+	breakpoints := []float64{0, 1, 2, 3, 4}
+	values := []float64{0, 1, 4, 9, 16}
+
+	for _, maximize := range []bool{false, true} {
+		// x is fixed at 2.5, between the breakpoints 2 and 3
+		model := Model{
+			Maximize: maximize,
+			ColLower: []float64{2.5},
+			ColUpper: []float64{2.5},
+		}
+		y, err := model.AddPiecewiseLinear(0, breakpoints, values)
+		if err != nil {
+			t.Fatalf("AddPiecewiseLinear failed: %v", err)
+		}
+		if y != 10 || model.NumVars() != 11 {
+			t.Fatalf("y = %d with %d variables, expected 10 and 11", y, model.NumVars())
+		}
+		if err := model.SetObjectiveSparse(map[int]float64{y: 1}); err != nil {
+			t.Fatalf("SetObjectiveSparse failed: %v", err)
+		}
+
+		sol, err := model.Solve(WithOutput(false))
+		if err != nil {
+			t.Fatalf("Solve failed: %v", err)
+		}
+		if !sol.IsOptimal() {
+			t.Fatalf("Expected optimal, got %s", sol.Status)
+		}
+		// Without the SOS2 encoding, maximizing could mix 0 and 16
+		if !almostEqual(sol.ColValues[y], 6.5, 1e-6) {
+			t.Errorf("maximize=%v: y = %g, expected 6.5", maximize, sol.ColValues[y])
+		}
+	}
+
+	var model Model
+	model.ColUpper = []float64{1}
+	if _, err := model.AddPiecewiseLinear(0, []float64{0, 0}, []float64{1, 2}); err == nil {
+		t.Error("expected error for non-increasing breakpoints")
+	}
+	if _, err := model.AddPiecewiseLinear(1, []float64{0, 1}, []float64{1, 2}); err == nil {
+		t.Error("expected error for an unknown column")
+	}
+}
+
+// TestSolveContext tests that canceling the context interrupts a solve,
+// that a done context does not start one, and that an open one solves to
+// optimality.
+func TestSolveContext(t *testing.T) {
+	model := knapsackModel(60, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel from the progress reporter so the interrupt is deterministic.
+	sol, err := model.SolveContext(ctx, WithOutput(false), WithProgressReporter(func(p Progress) {
+		cancel()
+	}))
+	if !errors.Is(err, ErrCanceled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, expected ErrCanceled wrapping context.Canceled", err)
+	}
+	if sol == nil || sol.Status != ModelStatusInterrupt {
+		t.Fatalf("solution = %+v, expected status Interrupt", sol)
+	}
+
+	// An already-done context does not start the solve.
+	if _, err := model.SolveContext(ctx, WithOutput(false)); !errors.Is(err, ErrCanceled) {
+		t.Errorf("err = %v, expected ErrCanceled", err)
+	}
+
+	// A context that is never canceled solves to optimality.
+	small := knapsackModel(10, 2)
+	sol, err = small.SolveContext(context.Background(), WithOutput(false))
+	if err != nil {
+		t.Fatalf("SolveContext failed: %v", err)
+	}
+	if sol.Status != ModelStatusOptimal {
+		t.Errorf("status = %v, expected Optimal", sol.Status)
+	}
+}
+
+// TestWithInterruptOn tests that a signal interrupts a MIP solve with
+// its incumbent and that the solve otherwise runs to optimality.
+func TestWithInterruptOn(t *testing.T) {
+	model := knapsackModel(60, 8)
+	signals := make(chan os.Signal, 1)
+
+	// Signal once an incumbent exists, so the interrupted solve has one
+	sol, err := model.Solve(WithOutput(false), WithInterruptOn(signals), WithProgressReporter(func(p Progress) {
+		if !math.IsInf(p.Incumbent, 0) && !p.Done {
+			select {
+			case signals <- os.Interrupt:
+			default:
+			}
+		}
+	}))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Status != ModelStatusInterrupt {
+		t.Fatalf("status = %v, expected Interrupt", sol.Status)
+	}
+	if !sol.Populated || len(sol.ColValues) != 60 {
+		t.Errorf("Expected the incumbent, got Populated %v with %d values", sol.Populated, len(sol.ColValues))
+	}
+
+	// Without a signal the solve runs to optimality
+	small := knapsackModel(10, 2)
+	if sol, err = small.Solve(WithOutput(false), WithInterruptOn(make(chan os.Signal))); err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Status != ModelStatusOptimal {
+		t.Errorf("status = %v, expected Optimal", sol.Status)
+	}
+}
+
+// TestNonConvexQPWarning tests that a QP with an indefinite Hessian still
+// returns its solution, flagged as possibly only locally optimal.
+func TestNonConvexQPWarning(t *testing.T) {
+	model := Model{
+		ColCosts:    []float64{0, 0},
+		ColLower:    []float64{-1, -1},
+		ColUpper:    []float64{1, 1},
+		ConstMatrix: []Nonzero{{0, 0, 1}, {0, 1, 1}},
+		RowLower:    []float64{-10},
+		RowUpper:    []float64{10},
+		// [[1, 3], [3, 1]] has eigenvalues 4 and -2
+		Hessian: []Nonzero{{0, 0, 1}, {0, 1, 3}, {1, 1, 1}},
+	}
+
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.Populated || len(sol.ColValues) != 2 {
+		t.Fatalf("Expected a solution, got %+v", sol)
+	}
+	found := false
+	for _, w := range sol.Warnings {
+		found = found || strings.Contains(w, "local optimum")
+	}
+	if !found {
+		t.Errorf("Warnings = %q, expected a non-convexity warning", sol.Warnings)
+	}
+
+	// A convex Hessian raises no warning
+	model.Hessian = []Nonzero{{0, 0, 2}, {0, 1, 1}, {1, 1, 2}}
+	sol, err = model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	for _, w := range sol.Warnings {
+		if strings.Contains(w, "local optimum") {
+			t.Errorf("Unexpected warning for a convex QP: %q", w)
+		}
+	}
+
+	// A negative semidefinite Hessian is convex when maximizing
+	if c := hessianConvexity([]Nonzero{{0, 0, -1}, {0, 1, -1}, {1, 1, -1}}, true); c != convexityConvex {
+		t.Errorf("Expected a negative semidefinite Hessian to be convex for maximization, got %d", c)
+	}
+	if c := hessianConvexity([]Nonzero{{0, 0, 1}, {1, 1, 0}, {0, 1, 1}}, false); c != convexityNonConvex {
+		t.Errorf("Expected [[1, 1], [1, 0]] to be non-convex, got %d", c)
+	}
+
+	// Hessians too large to factorize are reported as unchecked
+	large := make([]Nonzero, maxConvexityCheckDim+1)
+	for i := range large {
+		large[i] = Nonzero{i, i, 1}
+	}
+	if c := hessianConvexity(large, false); c != convexityUnchecked {
+		t.Errorf("Expected a Hessian with %d columns to be unchecked, got %d", len(large), c)
+	}
+
+	// A Hessian read from a file has not been checked
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "qp.mps")
+	if err := solver.WriteModel(path); err != nil {
+		t.Fatalf("WriteModel failed: %v", err)
+	}
+	if err := solver.ReadModel(path); err != nil {
+		t.Fatalf("ReadModel failed: %v", err)
+	}
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	sol, err = solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	found = false
+	for _, w := range sol.Warnings {
+		found = found || w == uncheckedQPWarning
+	}
+	if !found {
+		t.Errorf("Warnings = %q, expected %q", sol.Warnings, uncheckedQPWarning)
+	}
+}
+
+// TestSetInterruptCallback tests stopping a MIP solve at its first
+// incumbent, and that removing the callback lets the solve finish.
+func TestSetInterruptCallback(t *testing.T) {
+	model := knapsackModel(60, 8)
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	// Stop at the first incumbent
+	var incumbent float64
+	calls := 0
+	err = solver.SetInterruptCallback(func(data CallbackData) bool {
+		calls++
+		if data.MIP && !math.IsInf(data.Incumbent, 0) {
+			incumbent = data.Incumbent
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		t.Fatalf("SetInterruptCallback failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if calls == 0 {
+		t.Fatal("callback was never called")
+	}
+	if sol.Status != ModelStatusInterrupt {
+		t.Fatalf("status = %v, expected Interrupt", sol.Status)
+	}
+	if !sol.Populated || !almostEqual(sol.Objective, incumbent, 1e-6) {
+		t.Errorf("objective = %v (populated %v), expected incumbent %v", sol.Objective, sol.Populated, incumbent)
+	}
+
+	// Removing the callback lets the solve finish
+	if err := solver.SetInterruptCallback(nil); err != nil {
+		t.Fatalf("SetInterruptCallback failed: %v", err)
+	}
+	if err := solver.ClearSolver(); err != nil {
+		t.Fatalf("ClearSolver failed: %v", err)
+	}
+	sol, err = solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if sol.Status != ModelStatusOptimal {
+		t.Errorf("status = %v, expected Optimal", sol.Status)
+	}
+}
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+// TestWithLogWriter tests that the HiGHS log reaches the writer and that
+// a failing writer does not fail the solve.
+func TestWithLogWriter(t *testing.T) {
+	model := Model{
+		ColCosts:    []float64{1.0, 1.0},
+		ColLower:    []float64{0.0, 1.0},
+		ColUpper:    []float64{4.0, 1e30},
+		ConstMatrix: []Nonzero{{0, 1, 1.0}, {1, 0, 1.0}, {1, 1, 2.0}, {2, 0, 3.0}, {2, 1, 2.0}},
+		RowLower:    []float64{-1e30, 5.0, 6.0},
+		RowUpper:    []float64{7.0, 15.0, 1e30},
+	}
+
+	var buf bytes.Buffer
+	sol, err := model.Solve(WithOutput(false), WithLogWriter(&buf))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	if !strings.Contains(buf.String(), "Model status") {
+		t.Errorf("log = %q, expected the solve summary", buf.String())
+	}
+
+	// A failing writer does not fail the solve
+	sol, err = model.Solve(WithOutput(false), WithLogWriter(failingWriter{}))
+	if err != nil {
+		t.Fatalf("Solve with failing writer failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Errorf("Expected optimal, got %s", sol.Status)
+	}
+}
+
+// TestChangeCoeff tests changing, removing, and rejecting single matrix
+// coefficients.
+func TestChangeCoeff(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+
+	// Minimizing x0 + x1 subject to x0 + 2 x1 >= 5 favours x1
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !almostEqual(sol.Objective, 2.5, 1e-6) {
+		t.Fatalf("Objective = %v, expected 2.5", sol.Objective)
+	}
+
+	// Halving x1's coefficient makes x0 the cheaper way to cover the row
+	if err := solver.ChangeCoeff(0, 1, 0.5); err != nil {
+		t.Fatalf("ChangeCoeff failed: %v", err)
+	}
+	if sol, err = solver.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !almostEqual(sol.Objective, 5, 1e-6) || !almostEqual(sol.ColValues[0], 5, 1e-6) {
+		t.Errorf("Objective = %v at %v, expected 5 at x0 = 5", sol.Objective, sol.ColValues)
+	}
+
+	// A zero value removes the entry
+	if err := solver.ChangeCoeff(0, 1, 0); err != nil {
+		t.Fatalf("ChangeCoeff failed: %v", err)
+	}
+	if _, _, cols, _, err := solver.GetRow(0); err != nil || !reflect.DeepEqual(cols, []int{0}) {
+		t.Errorf("row 0 columns = %v (err %v), expected [0]", cols, err)
+	}
+
+	if err := solver.ChangeCoeff(1, 0, 1); err == nil {
+		t.Error("Expected an error for an out-of-range row")
+	}
+	if err := solver.ChangeCoeff(0, 0, math.NaN()); err == nil {
+		t.Error("Expected an error for a NaN value")
+	}
+}
+
+// TestChangeCoeffs tests that coefficient batches edited in place and
+// by rebuilding the matrix both match the edited model and keep the
+// column names.
+func TestChangeCoeffs(t *testing.T) {
+	// check applies size random edits and compares the solver's matrix
+	// with the edited model.
+	check := func(size int) {
+		model := randomModel(40, 40, 0.1)
+		model.Maximize = true
+		solver, err := NewSolver()
+		if err != nil {
+			t.Fatalf("NewSolver failed: %v", err)
+		}
+		defer solver.Close()
+		if err := solver.SetBoolOption("output_flag", false); err != nil {
+			t.Fatalf("SetBoolOption failed: %v", err)
+		}
+		if err := model.load(solver); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+		names := make([]string, 40)
+		for i := range names {
+			names[i] = fmt.Sprintf("x%d", i)
+		}
+		if err := solver.SetColNames(names, true); err != nil {
+			t.Fatalf("SetColNames failed: %v", err)
+		}
+
+		rng := rand.New(rand.NewPCG(7, 8))
+		var edits []Nonzero
+		for len(edits) < size {
+			edits = append(edits, Nonzero{Row: rng.IntN(40), Col: rng.IntN(40), Val: float64(rng.IntN(4))})
+		}
+		if err := solver.ChangeCoeffs(edits); err != nil {
+			t.Fatalf("size %d: ChangeCoeffs failed: %v", size, err)
+		}
+
+		// Apply the same edits to the model, later entries winning
+		edited := make(map[[2]int]float64)
+		for _, nz := range model.ConstMatrix {
+			edited[[2]int{nz.Row, nz.Col}] += nz.Val
+		}
+		for _, nz := range edits {
+			edited[[2]int{nz.Row, nz.Col}] = nz.Val
+		}
+		got, err := solver.GetModel()
+		if err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		gotMatrix := make(map[[2]int]float64)
+		for _, nz := range got.ConstMatrix {
+			gotMatrix[[2]int{nz.Row, nz.Col}] = nz.Val
+		}
+		for pos, val := range edited {
+			if gotMatrix[pos] != val {
+				t.Errorf("size %d: coefficient %v = %v, expected %v", size, pos, gotMatrix[pos], val)
+			}
+		}
+		for pos := range gotMatrix {
+			if edited[pos] == 0 {
+				t.Errorf("size %d: unexpected coefficient at %v", size, pos)
+			}
+		}
+		if got, err := solver.ColNames(); err != nil || !reflect.DeepEqual(got, names) {
+			t.Errorf("size %d: column names = %v, expected them preserved", size, got)
+		}
+	}
+	// Small batches edit in place, large ones rebuild; both must agree
+	check(5)
+	check(2 * changeCoeffsRebuildMin)
+
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+	if err := solver.ChangeCoeffs([]Nonzero{{0, 5, 1}}); err == nil {
+		t.Error("Expected an error for an out-of-range column")
+	}
+	if err := solver.ChangeCoeffs([]Nonzero{{0, 0, math.NaN()}}); err == nil {
+		t.Error("Expected an error for a NaN value")
+	}
+}
+
+// TestSolveWithProgress tests that progress events arrive while the solve
+// runs, with node counts that never decrease and improving solutions no
+// better than the optimum, and that an unread channel does not block the
+// solve.
+func TestSolveWithProgress(t *testing.T) {
+	model := knapsackModel(40, 5)
+
+	// Hold the solve at its final report until an event has arrived
+	release := make(chan struct{})
+	progress := model.SolveWithProgress(WithOutput(false), WithProgressReporter(func(p Progress) {
+		if p.Done {
+			<-release
+		}
+	}))
+	first, ok := <-progress.Events()
+	if !ok {
+		close(release)
+		t.Fatal("Expected at least one progress event")
+	}
+	select {
+	case <-progress.done:
+		t.Error("Expected the first event before the solve finished")
+	default:
+	}
+	close(release)
+
+	got := []MIPProgress{first}
+	for p := range progress.Events() {
+		got = append(got, p)
+	}
+	sol, err := progress.Wait()
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	improving := false
+	for i, p := range got {
+		if i > 0 && p.Nodes < got[i-1].Nodes {
+			t.Errorf("event %d: nodes went from %d to %d", i, got[i-1].Nodes, p.Nodes)
+		}
+		if p.Improving {
+			improving = true
+			if p.Objective > sol.Objective+1e-6 {
+				t.Errorf("event %d: incumbent %v beats the optimum %v", i, p.Objective, sol.Objective)
+			}
+		}
+	}
+	if !improving {
+		t.Error("Expected an improving-solution event")
+	}
+
+	// Events nobody reads are dropped once the buffer is full
+	progress = model.SolveWithProgress(WithOutput(false))
+	if sol, err := progress.Wait(); err != nil || !sol.IsOptimal() {
+		t.Fatalf("Solve without a reader = %v, %v, expected optimal", sol, err)
+	}
+	n := 0
+	for range progress.Events() {
+		n++
+	}
+	if n == 0 || n > mipProgressBuffer {
+		t.Errorf("%d buffered events, expected between 1 and %d", n, mipProgressBuffer)
+	}
+}
+
+// TestModelNamesWritten tests that model names reach the written file,
+// that surplus names are ignored, and that unnamed columns get default
+// names.
+func TestModelNamesWritten(t *testing.T) {
+	model := Model{
+		ColCosts:    []float64{1, 2},
+		ColLower:    []float64{0, 0},
+		ColUpper:    []float64{10, 10},
+		ConstMatrix: []Nonzero{{0, 0, 1}, {0, 1, 1}},
+		RowLower:    []float64{1},
+		RowUpper:    []float64{Inf()},
+		// A surplus name does not add a column
+		ColNames: []string{"widgets", "gadgets", "surplus"},
+		RowNames: []string{"demand"},
+	}
+	if n := model.NumVars(); n != 2 {
+		t.Fatalf("NumVars = %d, expected 2", n)
+	}
+
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "model.lp")
+	if err := solver.WriteModel(path); err != nil {
+		t.Fatalf("WriteModel failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	for _, name := range []string{"widgets", "gadgets", "demand"} {
+		if !bytes.Contains(data, []byte(name)) {
+			t.Errorf("LP file lacks name %q:\n%s", name, data)
+		}
+	}
+	if bytes.Contains(data, []byte("surplus")) {
+		t.Errorf("LP file contains the surplus name:\n%s", data)
+	}
+
+	// Unnamed columns get the default names
+	model.ColNames = []string{"", "gadgets"}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if names, _ := solver.ColNames(); !reflect.DeepEqual(names, []string{"C0", "gadgets"}) {
+		t.Errorf("column names = %q, expected [C0 gadgets]", names)
+	}
+
+	// A default name already given to another column gets a suffix
+	model.ColNames = []string{"", "C0"}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if names, _ := solver.ColNames(); !reflect.DeepEqual(names, []string{"C0_2", "C0"}) {
+		t.Errorf("column names = %q, expected [C0_2 C0]", names)
+	}
+}
+
+// TestWithStopAtFirstFeasible tests that a MIP solve stops with a
+// feasible solution before it starts branching.
+func TestWithStopAtFirstFeasible(t *testing.T) {
+	model := knapsackModel(60, 8)
+	lastEvent := func(events <-chan MIPProgress) (last MIPProgress, improving int) {
+		for p := range events {
+			if p.Improving {
+				improving++
+			}
+			last = p
+		}
+		return last, improving
+	}
+	progress := model.SolveWithProgress(WithOutput(false))
+	fullLast, fullImproving := lastEvent(progress.Events())
+	full, err := progress.Wait()
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+
+	progress = model.SolveWithProgress(WithOutput(false), WithStopAtFirstFeasible())
+	last, improving := lastEvent(progress.Events())
+	sol, err := progress.Wait()
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Status != ModelStatusInterrupt {
+		t.Fatalf("status = %v, expected Interrupt", sol.Status)
+	}
+	if !sol.Populated || improving == 0 {
+		t.Fatal("Expected a feasible solution to be returned")
+	}
+
+	// The root heuristics find several solutions before the first interrupt
+	// check, but the solve stops before branching
+	if improving >= fullImproving || last.Nodes >= fullLast.Nodes {
+		t.Errorf("stopped after %d solutions and %d nodes, full solve took %d and %d",
+			improving, last.Nodes, fullImproving, fullLast.Nodes)
+	}
+	if sol.Objective > full.Objective+1e-6 {
+		t.Errorf("objective %v beats the optimum %v", sol.Objective, full.Objective)
+	}
+	for i, row := range model.RowUpper {
+		if sol.RowValues[i] > row+1e-6 {
+			t.Errorf("row %d: activity %v exceeds %v", i, sol.RowValues[i], row)
+		}
+	}
+}
+
+// TestColRowNames tests that column and row names survive writing and
+// reading a model, and that a model without names has none to return.
+func TestColRowNames(t *testing.T) {
+	model := Model{
+		ColCosts:    []float64{1, 2},
+		ColLower:    []float64{0, 0},
+		ColUpper:    []float64{10, 10},
+		ConstMatrix: []Nonzero{{0, 0, 1}, {0, 1, 1}},
+		RowLower:    []float64{1},
+		RowUpper:    []float64{Inf()},
+		ColNames:    []string{"widgets", "gadgets"},
+		RowNames:    []string{"demand"},
+	}
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "model.mps")
+	if err := solver.WriteModel(path); err != nil {
+		t.Fatalf("WriteModel failed: %v", err)
+	}
+
+	// Read the names back from a fresh solver
+	reader, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer reader.Close()
+	if err := reader.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+	if err := reader.ReadModel(path); err != nil {
+		t.Fatalf("ReadModel failed: %v", err)
+	}
+	cols, err := reader.ColNames()
+	if err != nil {
+		t.Fatalf("ColNames failed: %v", err)
+	}
+	if !reflect.DeepEqual(cols, model.ColNames) {
+		t.Errorf("ColNames = %q, expected %q", cols, model.ColNames)
+	}
+	if name, err := reader.ColName(1); err != nil || name != "gadgets" {
+		t.Errorf("ColName(1) = %q, %v, expected gadgets", name, err)
+	}
+	if name, err := reader.RowName(0); err != nil || name != "demand" {
+		t.Errorf("RowName(0) = %q, %v, expected demand", name, err)
+	}
+	if _, err := reader.ColName(2); err == nil {
+		t.Error("Expected an error for an out-of-range column")
+	}
+
+	// An unnamed model has no names to return
+	unnamed := newRunIntoSolver(t)
+	defer unnamed.Close()
+	if _, err := unnamed.ColNames(); err == nil {
+		t.Error("Expected an error for a model without names")
+	}
+}
+
+// TestSaveLoadBundle tests that a saved bundle loads back the model,
+// options, and solution.
+func TestSaveLoadBundle(t *testing.T) {
+	model := Model{
+		Maximize:    true,
+		Offset:      2,
+		ColCosts:    []float64{3, 2, 1},
+		ColLower:    []float64{0, 0, 0},
+		ColUpper:    []float64{4, 5, Inf()},
+		ConstMatrix: []Nonzero{{0, 0, 1}, {0, 1, 1}, {1, 1, 1}, {1, 2, 2}},
+		RowLower:    []float64{NegInf(), 1},
+		RowUpper:    []float64{6, 8},
+		VarTypes:    []VariableType{Integer, Continuous, Continuous},
+		ColNames:    []string{"x", "y", "z"},
+		RowNames:    []string{"cap", "mix"},
+	}
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "case.zip")
+	tracePath := filepath.Join(dir, "trace.log")
+	if err := model.SaveBundle(path, sol, WithTimeLimit(30), WithSolveTrace(tracePath)); err != nil {
+		t.Fatalf("SaveBundle failed: %v", err)
+	}
+	if _, err := os.Stat(tracePath); !os.IsNotExist(err) {
+		t.Errorf("SaveBundle created the trace file (%v), expected no log options applied", err)
+	}
+	gotModel, gotSol, err := LoadBundle(path)
+	if err != nil {
+		t.Fatalf("LoadBundle failed: %v", err)
+	}
+
+	if gotModel.Maximize != model.Maximize || gotModel.Offset != model.Offset {
+		t.Errorf("sense and offset = %v, %v, expected %v, %v", gotModel.Maximize, gotModel.Offset, model.Maximize, model.Offset)
+	}
+	for name, pair := range map[string][2][]float64{
+		"ColCosts": {gotModel.ColCosts, model.ColCosts},
+		"ColLower": {gotModel.ColLower, model.ColLower},
+		"ColUpper": {gotModel.ColUpper, model.ColUpper},
+		"RowLower": {gotModel.RowLower, model.RowLower},
+		"RowUpper": {gotModel.RowUpper, model.RowUpper},
+	} {
+		if !reflect.DeepEqual(pair[0], pair[1]) {
+			t.Errorf("%s = %v, expected %v", name, pair[0], pair[1])
+		}
+	}
+	if !reflect.DeepEqual(gotModel.ConstMatrix, model.ConstMatrix) {
+		t.Errorf("ConstMatrix = %v, expected %v", gotModel.ConstMatrix, model.ConstMatrix)
+	}
+	if !reflect.DeepEqual(gotModel.VarTypes, model.VarTypes) {
+		t.Errorf("VarTypes = %v, expected %v", gotModel.VarTypes, model.VarTypes)
+	}
+	if !reflect.DeepEqual(gotModel.ColNames, model.ColNames) || !reflect.DeepEqual(gotModel.RowNames, model.RowNames) {
+		t.Errorf("names = %q, %q, expected %q, %q", gotModel.ColNames, gotModel.RowNames, model.ColNames, model.RowNames)
+	}
+	if !reflect.DeepEqual(gotSol, sol) {
+		t.Errorf("solution = %+v, expected %+v", gotSol, sol)
+	}
+
+	// The bundle records the options that were set
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer zr.Close()
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if expected := []string{"model.mps", "options.txt", "solution.bin"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("bundle entries = %q, expected %q", names, expected)
+	}
+	for _, f := range zr.File {
+		if f.Name != "options.txt" {
+			continue
+		}
+		data, err := readZipEntry(f)
+		if err != nil {
+			t.Fatalf("readZipEntry failed: %v", err)
+		}
+		if !bytes.Contains(data, []byte("time_limit")) {
+			t.Errorf("options.txt lacks time_limit:\n%s", data)
+		}
+	}
+
+	// A bundle without a solution loads a nil one
+	if err := model.SaveBundle(path, nil); err != nil {
+		t.Fatalf("SaveBundle failed: %v", err)
+	}
+	if _, gotSol, err := LoadBundle(path); err != nil || gotSol != nil {
+		t.Errorf("LoadBundle = %v, %v, expected a nil solution", gotSol, err)
+	}
+
+	// Invalid options fail before the bundle file is touched
+	if err := model.SaveBundle(path, nil, WithPresolve("sometimes")); err == nil {
+		t.Error("Expected an error for an invalid option")
+	}
+	if _, _, err := LoadBundle(path); err != nil {
+		t.Errorf("LoadBundle after a failed save: %v, expected the earlier bundle intact", err)
+	}
+}
+
+// TestAddCol tests adding a column to a loaded model and re-solving.
+func TestAddCol(t *testing.T) {
+	solver, err := NewSolver()
+	if err != nil {
+		t.Fatalf("NewSolver failed: %v", err)
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		t.Fatalf("SetBoolOption failed: %v", err)
+	}
+
+	// Minimize x0 + x1 subject to x0 >= 2 and x1 >= 3
+	model := Model{
+		ColCosts:    []float64{1, 1},
+		ColLower:    []float64{0, 0},
+		ColUpper:    []float64{10, 10},
+		ConstMatrix: []Nonzero{{0, 0, 1}, {1, 1, 1}},
+		RowLower:    []float64{2, 3},
+		RowUpper:    []float64{Inf(), Inf()},
+	}
+	if err := model.load(solver); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !almostEqual(sol.Objective, 5, 1e-9) {
+		t.Fatalf("Objective = %v, expected 5", sol.Objective)
+	}
+
+	// A column covering both rows at cost 1.5 replaces x0 and part of x1
+	if err := solver.AddCol(1.5, 0, 10, []int{0, 1}, []float64{1, 1}); err != nil {
+		t.Fatalf("AddCol failed: %v", err)
+	}
+	if n := solver.NumCol(); n != 3 {
+		t.Fatalf("NumCol = %d, expected 3", n)
+	}
+	sol, err = solver.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !almostEqual(sol.Objective, 4, 1e-9) || !almostEqual(sol.ColValues[2], 2, 1e-9) {
+		t.Errorf("Objective = %v with x2 = %v, expected 4 with x2 = 2", sol.Objective, sol.ColValues[2])
+	}
+
+	if err := solver.AddCol(1, 0, 1, []int{0}, nil); err == nil {
+		t.Error("Expected an error for mismatched rows and values")
+	}
+	if err := solver.AddCol(1, 0, 1, []int{2}, []float64{1}); err == nil {
+		t.Error("Expected an error for an out-of-range row")
+	}
+}
+
+// TestAddCols tests adding columns in compressed column form and
+// rejecting malformed input.
+func TestAddCols(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+	numCol, numNz := solver.NumCol(), solver.NumNonzero()
+
+	// Three columns: one in row 0, one empty, one in row 0 again
+	err := solver.AddCols(
+		[]float64{1, 2, 3},
+		[]float64{0, 0, 0},
+		[]float64{1, 1, 1},
+		[]int{0, 1, 1},
+		[]int{0, 0},
+		[]float64{4, 5},
+	)
+	if err != nil {
+		t.Fatalf("AddCols failed: %v", err)
+	}
+	if got := solver.NumCol(); got != numCol+3 {
+		t.Errorf("NumCol = %d, expected %d", got, numCol+3)
+	}
+	if got := solver.NumNonzero(); got != numNz+2 {
+		t.Errorf("NumNonzero = %d, expected %d", got, numNz+2)
+	}
+	cols, err := solver.GetColsByRange(numCol, numCol+2)
+	if err != nil {
+		t.Fatalf("GetColsByRange failed: %v", err)
+	}
+	if !reflect.DeepEqual(cols.Cost, []float64{1, 2, 3}) {
+		t.Errorf("costs = %v, expected [1 2 3]", cols.Cost)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		starts []int
+		index  []int
+		value  []float64
+	}{
+		{"short starts", []int{0}, nil, nil},
+		{"nonzero first start", []int{1, 1, 1}, []int{0}, []float64{1}},
+		{"decreasing starts", []int{0, 1, 0}, []int{0}, []float64{1}},
+		{"mismatched values", []int{0, 0, 0}, []int{0}, nil},
+		{"row out of range", []int{0, 0, 0}, []int{1}, []float64{1}},
+	} {
+		if err := solver.AddCols([]float64{0, 0, 0}, []float64{0, 0, 0}, []float64{1, 1, 1}, tc.starts, tc.index, tc.value); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+// TestClassifyUnboundedOrInfeasible tests that a MIP HiGHS reports as
+// UnboundedOrInfeasible is resolved by solving its LP relaxation.
+func TestClassifyUnboundedOrInfeasible(t *testing.T) {
+	// Maximize x + y subject to x - y <= 1 with integer x: unbounded
+	model := Model{
+		Maximize:    true,
+		ColCosts:    []float64{1, 1},
+		ColLower:    []float64{0, 0},
+		ColUpper:    []float64{Inf(), Inf()},
+		ConstMatrix: []Nonzero{{0, 0, 1}, {0, 1, -1}},
+		RowLower:    []float64{NegInf()},
+		RowUpper:    []float64{1},
+		VarTypes:    []VariableType{Integer, Continuous},
+	}
+	sol, err := model.Solve(WithOutput(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if sol.Status != ModelStatusUnbounded {
+		t.Fatalf("status = %v, expected Unbounded", sol.Status)
+	}
+	found := false
+	for _, w := range sol.Warnings {
+		found = found || strings.Contains(w, "classified as Unbounded")
+	}
+	if !found {
+		t.Errorf("Warnings = %q, expected the classification", sol.Warnings)
+	}
+	if sol.StatusClassifiedFrom != ModelStatusUnboundedOrInfeasible {
+		t.Errorf("StatusClassifiedFrom = %v, expected UnboundedOrInfeasible", sol.StatusClassifiedFrom)
+	}
+}
+
+// TestEliminateFixedVariables tests that removing the fixed variables
+// of a QP preserves its optimum.
+func TestEliminateFixedVariables(t *testing.T) {
+	// A QP with three of five variables fixed, touching the costs, rows,
+	// and both diagonal and off-diagonal Hessian entries
+	model := Model{
+		Offset:   1.5,
+		ColCosts: []float64{1, -2, 3, 0.5, -1},
+		ColLower: []float64{2, 0, -1, 0, 0.25},
+		ColUpper: []float64{2, 10, -1, 10, 0.25},
+		ConstMatrix: []Nonzero{
+			{0, 0, 1}, {0, 1, 1}, {0, 3, 2},
+			{1, 1, 1}, {1, 2, 4}, {1, 4, -2},
+			{2, 0, 3}, {2, 2, 1}, {2, 4, 8},
+		},
+		RowLower: []float64{4, NegInf(), 7},
+		RowUpper: []float64{20, 6, 7},
+		Hessian: []Nonzero{
+			{0, 0, 2}, {0, 1, 0.5}, {1, 1, 1}, {1, 3, 0.25}, {2, 2, 3}, {2, 4, -1}, {3, 3, 2}, {4, 4, 1},
+		},
+		ColNames: []string{"a", "b", "c", "d", "e"},
+	}
+	want, err := model.Solve(WithOutput(false))
+	if err != nil || !want.IsOptimal() {
+		t.Fatalf("Solve failed: %v, %v", err, want)
+	}
+
+	reduced := model
+	elim, err := reduced.EliminateFixedVariables()
+	if err != nil {
+		t.Fatalf("EliminateFixedVariables failed: %v", err)
+	}
+	if !reflect.DeepEqual(elim.Kept, []int{1, 3}) || len(elim.Fixed) != 3 {
+		t.Fatalf("Kept %v, Fixed %v, expected [1 3] kept and three fixed", elim.Kept, elim.Fixed)
+	}
+	if reduced.NumVars() != 2 || !reflect.DeepEqual(reduced.ColNames, []string{"b", "d"}) {
+		t.Errorf("reduced model has %d columns named %q", reduced.NumVars(), reduced.ColNames)
+	}
+	if model.NumVars() != 5 || model.RowLower[0] != 4 {
+		t.Error("EliminateFixedVariables modified the original model's slices")
+	}
+
+	got, err := reduced.Solve(WithOutput(false))
+	if err != nil || !got.IsOptimal() {
+		t.Fatalf("reduced Solve failed: %v, %v", err, got)
+	}
+	if math.Abs(got.Objective-want.Objective) > 1e-9 {
+		t.Errorf("reduced objective = %.12g, expected %.12g", got.Objective, want.Objective)
+	}
+	full := elim.ExpandColValues(got.ColValues)
+	for col, v := range full {
+		if !almostEqual(v, want.ColValues[col], 1e-6) {
+			t.Errorf("x%d = %v, expected %v", col, v, want.ColValues[col])
+		}
+	}
+
+	// With every variable fixed, the objective is the accumulated offset
+	allFixed := Model{
+		Offset:      1,
+		ColCosts:    []float64{2, 3},
+		ColLower:    []float64{1, 2},
+		ColUpper:    []float64{1, 2},
+		ConstMatrix: []Nonzero{{0, 0, 1}, {0, 1, 1}},
+		RowLower:    []float64{3},
+		RowUpper:    []float64{3},
+	}
+	if _, err := allFixed.EliminateFixedVariables(); err != nil {
+		t.Fatalf("EliminateFixedVariables failed: %v", err)
+	}
+	sol, err := allFixed.Solve(WithOutput(false))
+	if err != nil || !sol.IsOptimal() || math.Abs(sol.Objective-9) > 1e-9 {
+		t.Errorf("all-fixed Solve = %v, %v, expected Optimal with objective 9", sol, err)
+	}
+
+	fractional := Model{
+		ColLower: []float64{0.5},
+		ColUpper: []float64{0.5},
+		VarTypes: []VariableType{Integer},
+	}
+	if _, err := fractional.EliminateFixedVariables(); err == nil {
+		t.Error("Expected an error for an integer fixed at a fractional value")
+	}
+}
+
+// TestAggregateEqualities tests that substituting out a column through
+// an equality row preserves the optimum.
+func TestAggregateEqualities(t *testing.T) {
+	// z = x + y through row 0; the optimum is x = 2/3, y = 5/3
+	model := Model{
+		ColCosts: []float64{2, 3, 1},
+		ColLower: []float64{0, 0, 0},
+		ColUpper: []float64{Inf(), Inf(), Inf()},
+		ConstMatrix: []Nonzero{
+			{0, 0, -1}, {0, 1, -1}, {0, 2, 1},
+			{1, 0, 1}, {1, 1, 2},
+			{2, 0, 1}, {2, 2, 1},
+		},
+		RowLower: []float64{0, 4, 3},
+		RowUpper: []float64{0, Inf(), Inf()},
+		ColNames: []string{"x", "y", "z"},
+	}
+	want, err := model.Solve(WithOutput(false))
+	if err != nil || !want.IsOptimal() {
+		t.Fatalf("Solve failed: %v, %v", err, want)
+	}
+
+	reduced := model
+	agg, err := reduced.AggregateEqualities()
+	if err != nil {
+		t.Fatalf("AggregateEqualities failed: %v", err)
+	}
+	if len(agg.Substitutions) != 1 || len(agg.Kept) != 2 || reduced.NumVars() != 2 {
+		t.Fatalf("Kept %v, Substitutions %+v, expected one column removed", agg.Kept, agg.Substitutions)
+	}
+	if sub := agg.Substitutions[0]; sub.Row != 0 || len(reduced.ColNames) != 2 {
+		t.Errorf("substitution %+v with names %q, expected one from row 0 and two names", sub, reduced.ColNames)
+	}
+	if model.NumVars() != 3 || model.ColCosts[0] != 2 {
+		t.Error("AggregateEqualities modified the original model's slices")
+	}
+
+	got, err := reduced.Solve(WithOutput(false))
+	if err != nil || !got.IsOptimal() {
+		t.Fatalf("reduced Solve failed: %v, %v", err, got)
+	}
+	if !almostEqual(got.Objective, want.Objective, 1e-9) {
+		t.Errorf("reduced objective = %.12g, expected %.12g", got.Objective, want.Objective)
+	}
+	full := agg.ExpandColValues(got.ColValues)
+	for col, v := range full {
+		if !almostEqual(v, want.ColValues[col], 1e-6) {
+			t.Errorf("x%d = %v, expected %v", col, v, want.ColValues[col])
+		}
+	}
+
+	// Integer columns are never eliminated
+	integer := Model{
+		ColCosts:    []float64{1, 1},
+		ColUpper:    []float64{5, 5},
+		ConstMatrix: []Nonzero{{0, 0, 1}, {0, 1, 1}},
+		RowLower:    []float64{3},
+		RowUpper:    []float64{3},
+		VarTypes:    []VariableType{Integer, Integer},
+	}
+	if agg, err := integer.AggregateEqualities(); err != nil || len(agg.Substitutions) != 0 || integer.NumVars() != 2 {
+		t.Errorf("integer model: %+v, %v, expected no substitutions", agg, err)
+	}
+}
+
+// TestValidate tests that Validate accepts a consistent model and
+// rejects each inconsistency.
+func TestValidate(t *testing.T) {
+	valid := func() Model {
+		return Model{
+			ColCosts:    []float64{1, 1, 1},
+			ColLower:    []float64{0, 0, 0},
+			ColUpper:    []float64{4, 4, 4},
+			VarTypes:    []VariableType{Continuous, Integer, Continuous},
+			ConstMatrix: []Nonzero{{0, 0, 1}, {0, 1, 1}, {1, 2, 1}},
+			RowLower:    []float64{1, NegInf()},
+			RowUpper:    []float64{Inf(), 3},
+			Hessian:     []Nonzero{{0, 0, 2}, {0, 2, 1}, {2, 2, 2}},
+		}
+	}
+	m := valid()
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Validate of a valid model failed: %v", err)
+	}
+	empty := Model{ConstMatrix: []Nonzero{{0, 0, 1}}}
+	if err := empty.Validate(); err != nil {
+		t.Errorf("Validate with empty slices failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		field  string
+		modify func(m *Model)
+	}{
+		{"ColCosts", func(m *Model) { m.ColCosts = m.ColCosts[:2] }},
+		{"ColLower", func(m *Model) { m.ColLower = m.ColLower[:1] }},
+		{"ColUpper", func(m *Model) { m.ColUpper = m.ColUpper[:2] }},
+		{"VarTypes", func(m *Model) { m.VarTypes = m.VarTypes[:2] }},
+		{"RowLower", func(m *Model) { m.RowLower = m.RowLower[:1] }},
+		{"RowUpper", func(m *Model) { m.RowUpper = m.RowUpper[:1] }},
+		{"ConstMatrix", func(m *Model) { m.ConstMatrix = append(m.ConstMatrix, Nonzero{-1, 0, 1}) }},
+		{"Hessian", func(m *Model) { m.Hessian = append(m.Hessian, Nonzero{2, 0, 1}) }},
+		{"Hessian", func(m *Model) { m.Hessian = append(m.Hessian, Nonzero{-1, 1, 1}) }},
+	} {
+		m := valid()
+		tc.modify(&m)
+		err := m.Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.field) {
+			t.Errorf("%s: Validate = %v, expected an error naming %s", tc.field, err, tc.field)
+		}
+	}
+
+	// An off-diagonal term given in both orders is reported as such rather
+	// than as a triangularity error, also when solving without validation
+	m = valid()
+	m.Hessian = []Nonzero{{0, 0, 2}, {0, 1, 1}, {1, 0, 1}, {1, 1, 2}}
+	const transposed = "Hessian has entries at both (0, 1) and (1, 0)"
+	if err := m.Validate(); err == nil || !strings.Contains(err.Error(), transposed) {
+		t.Errorf("Validate = %v, expected an error containing %q", err, transposed)
+	}
+	if _, err := m.Solve(WithOutput(false)); err == nil || !strings.Contains(err.Error(), transposed) {
+		t.Errorf("Solve = %v, expected an error containing %q", err, transposed)
+	}
+
+	// Exact duplicates in the constraint matrix are still merged silently
+	m = valid()
+	m.ConstMatrix = append(m.ConstMatrix, Nonzero{0, 0, 1})
+	if err := m.Validate(); err != nil {
+		t.Errorf("Validate with a duplicate matrix entry failed: %v", err)
+	}
+
+	// WithValidation reports the field before loading; without it the
+	// length mismatch surfaces from loading
+	m = valid()
+	m.ColCosts = m.ColCosts[:2]
+	if _, err := m.Solve(WithOutput(false), WithValidation(true)); err == nil || !strings.Contains(err.Error(), "ColCosts has length 2, expected 0 or NumVars = 3") {
+		t.Errorf("Solve with validation = %v, expected the Validate error", err)
+	}
+	if _, err := m.Solve(WithOutput(false)); err == nil {
+		t.Error("Expected Solve without validation to fail too")
+	}
+}
+
+// TestGetCol tests reading back a single column's cost, bounds, and
+// entries.
+func TestGetCol(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+	if err := solver.AddRow(-1, 4, []int{1}, []float64{3}); err != nil {
+		t.Fatalf("AddRow failed: %v", err)
+	}
+	if err := solver.AddCol(2.5, -1, 7, []int{0, 1}, []float64{1.25, -3}); err != nil {
+		t.Fatalf("AddCol failed: %v", err)
+	}
+
+	cost, lower, upper, rows, values, err := solver.GetCol(2)
+	if err != nil {
+		t.Fatalf("GetCol failed: %v", err)
+	}
+	if cost != 2.5 || lower != -1 || upper != 7 {
+		t.Errorf("cost and bounds = %v, [%v, %v], expected 2.5, [-1, 7]", cost, lower, upper)
+	}
+	if !reflect.DeepEqual(rows, []int{0, 1}) || !reflect.DeepEqual(values, []float64{1.25, -3}) {
+		t.Errorf("entries = %v, %v, expected [0 1], [1.25 -3]", rows, values)
+	}
+
+	if _, _, _, _, _, err := solver.GetCol(3); err == nil {
+		t.Error("Expected an error for an out-of-range column")
+	}
+}
+
+// TestGetRow tests reading back a single row's bounds and entries.
+func TestGetRow(t *testing.T) {
+	solver := newRunIntoSolver(t)
+	defer solver.Close()
+	if err := solver.AddCol(0, 0, 1, nil, nil); err != nil {
+		t.Fatalf("AddCol failed: %v", err)
+	}
+	if err := solver.AddRow(-2, 6.5, []int{2, 0, 1}, []float64{-1.5, 4, 0.25}); err != nil {
+		t.Fatalf("AddRow failed: %v", err)
+	}
+
+	lower, upper, cols, values, err := solver.GetRow(1)
+	if err != nil {
+		t.Fatalf("GetRow failed: %v", err)
+	}
+	if lower != -2 || upper != 6.5 {
+		t.Errorf("bounds = [%v, %v], expected [-2, 6.5]", lower, upper)
+	}
+	got := make(map[int]float64)
+	for i, col := range cols {
+		got[col] = values[i]
+	}
+	if want := map[int]float64{0: 4, 1: 0.25, 2: -1.5}; len(cols) != 3 || !reflect.DeepEqual(got, want) {
+		t.Errorf("entries = %v, %v, expected %v", cols, values, want)
+	}
+
+	if _, _, _, _, err := solver.GetRow(2); err == nil {
+		t.Error("Expected an error for an out-of-range row")
+	}
+}
+
+// TestWithCollectSolutions tests that the feasible solutions of a MIP
+// solve are collected into a deduplicated pool.
+func TestWithCollectSolutions(t *testing.T) {
+	// Items share values, so the knapsack has several optima, two of
+	// which the solver finds without presolve
+	model := knapsackModel(25, 2)
+	for j := range model.ColCosts {
+		model.ColCosts[j] = float64(10 + 10*(j%3))
+	}
+	sol, err := model.Solve(WithOutput(false), WithPresolve("off"), WithCollectSolutions(100))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !sol.IsOptimal() {
+		t.Fatalf("Expected optimal, got %s", sol.Status)
+	}
+	seen := make(map[string]bool)
+	optima := 0
+	for i, e := range sol.Pool {
+		key := fmt.Sprint(e.ColValues)
+		if seen[key] {
+			t.Errorf("pool entry %d duplicates an earlier one", i)
+		}
+		seen[key] = true
+		if len(e.ColValues) != 25 {
+			t.Fatalf("pool entry %d has %d values, expected 25", i, len(e.ColValues))
+		}
+		objective := 0.0
+		for j, v := range e.ColValues {
+			objective += model.ColCosts[j] * v
+		}
+		if !almostEqual(objective, e.Objective, 1e-6) {
+			t.Errorf("pool entry %d has objective %v, expected %v from its values", i, e.Objective, objective)
+		}
+		if e.Objective > sol.Objective+1e-6 {
+			t.Errorf("pool entry %d has objective %v beyond the optimum %v", i, e.Objective, sol.Objective)
+		}
+		if almostEqual(e.Objective, sol.Objective, 1e-6) {
+			optima++
+		}
+	}
+	if optima < 2 {
+		t.Errorf("pool holds %d optimal solutions, expected at least 2", optima)
+	}
+
+	// The pool stops growing at max
+	sol, err = model.Solve(WithOutput(false), WithCollectSolutions(1))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if len(sol.Pool) != 1 {
+		t.Errorf("pool has %d entries, expected 1", len(sol.Pool))
+	}
+
+	// Follow-up solves, here for a secondary objective, add nothing
+	primary, err := model.Solve(WithOutput(false), WithCollectSolutions(100))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	sol, err = model.Solve(WithOutput(false), WithCollectSolutions(100),
+		WithSecondaryObjective(make([]float64, 25)))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if !reflect.DeepEqual(sol.Pool, primary.Pool) {
+		t.Errorf("pool with a secondary objective has %d entries, expected the %d of the main solve", len(sol.Pool), len(primary.Pool))
+	}
+}
+
 // Benchmarks
 
 func BenchmarkLPSolve(b *testing.B) {
@@ -362,3 +5195,134 @@ func BenchmarkLPSolve(b *testing.B) {
 		}
 	}
 }
+
+// randomModel builds a sparse LP with the given dimensions and density.
+func randomModel(numRow, numCol int, density float64) Model {
+	rng := rand.New(rand.NewPCG(1, 2))
+	model := Model{
+		ColCosts: make([]float64, numCol),
+		ColLower: make([]float64, numCol),
+		ColUpper: make([]float64, numCol),
+	}
+	for j := range model.ColCosts {
+		model.ColCosts[j] = rng.Float64()
+		model.ColUpper[j] = 10.0
+	}
+	for i := 0; i < numRow; i++ {
+		model.RowLower = append(model.RowLower, math.Inf(-1))
+		model.RowUpper = append(model.RowUpper, 100.0)
+		for j := 0; j < numCol; j++ {
+			// Keep every row and column nonempty
+			if j == i%numCol || i == j%numRow || rng.Float64() < density {
+				model.ConstMatrix = append(model.ConstMatrix, Nonzero{Row: i, Col: j, Val: rng.Float64()})
+			}
+		}
+	}
+	return model
+}
+
+func BenchmarkMatrixFormat(b *testing.B) {
+	shapes := []struct {
+		name           string
+		numRow, numCol int
+	}{
+		{"TallSkinny", 5000, 50},
+		{"ShortWide", 50, 5000},
+	}
+	for _, shape := range shapes {
+		model := randomModel(shape.numRow, shape.numCol, 0.05)
+		for _, format := range []MatrixFormat{MatrixFormatRowwise, MatrixFormatColwise} {
+			b.Run(shape.name+"/"+format.String(), func(b *testing.B) {
+				solver, err := NewSolver()
+				if err != nil {
+					b.Fatal(err)
+				}
+				defer solver.Close()
+				if err := solver.SetBoolOption("output_flag", false); err != nil {
+					b.Fatal(err)
+				}
+
+				for i := 0; i < b.N; i++ {
+					if err := model.loadFormat(solver, format); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkRunInto(b *testing.B) {
+	solver := newRunIntoSolver(b)
+	defer solver.Close()
+
+	var sol Solution
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := solver.RunInto(&sol); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkChangeCoeffs compares editing a sparse set of coefficients one
+// at a time with rebuilding the model in a single pass.
+func BenchmarkChangeCoeffs(b *testing.B) {
+	model := randomModel(500, 500, 0.02)
+	rng := rand.New(rand.NewPCG(9, 10))
+	edits := make([]Nonzero, 2000)
+	for i := range edits {
+		edits[i] = Nonzero{Row: rng.IntN(500), Col: rng.IntN(500), Val: 1 + rng.Float64()}
+	}
+	for _, bc := range []struct {
+		name  string
+		apply func(*Solver, []Nonzero) error
+	}{
+		{"Individual", (*Solver).changeCoeffsEach},
+		{"Rebuild", (*Solver).rebuildCoeffs},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			solver := newRunIntoSolver(b)
+			defer solver.Close()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				if err := model.load(solver); err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+				if err := bc.apply(solver, edits); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// knapsackModel builds a multi-dimensional 0/1 knapsack that needs
+// branching to solve.
+func knapsackModel(numItems, numDims int) Model {
+	rng := rand.New(rand.NewPCG(3, 4))
+	model := Model{
+		Maximize: true,
+		ColCosts: make([]float64, numItems),
+		ColLower: make([]float64, numItems),
+		ColUpper: make([]float64, numItems),
+		VarTypes: make([]VariableType, numItems),
+	}
+	for j := 0; j < numItems; j++ {
+		model.ColCosts[j] = float64(10 + rng.IntN(90))
+		model.ColUpper[j] = 1
+		model.VarTypes[j] = Integer
+	}
+	for i := 0; i < numDims; i++ {
+		weights := make([]float64, numItems)
+		total := 0.0
+		for j := range weights {
+			weights[j] = float64(5 + rng.IntN(60))
+			total += weights[j]
+		}
+		model.AddLeRow(weights, math.Floor(total/2))
+	}
+	return model
+}
@@ -0,0 +1,170 @@
+package highs
+
+import (
+	"fmt"
+	"math"
+)
+
+// relaxationTol is the violation above which a bound is reported as
+// needing relaxation.
+const relaxationTol = 1e-6
+
+// InfeasibilityReport explains why a model is infeasible.
+type InfeasibilityReport struct {
+	// ConflictingRows are the constraints of an irreducible infeasible
+	// subsystem (IIS): together they cannot be satisfied, but removing
+	// any one of them resolves that conflict. For a MIP the IIS is of the
+	// LP relaxation and may be empty if only integrality causes the
+	// infeasibility.
+	ConflictingRows []int
+
+	// ConflictingCols are the variables whose bounds belong to the IIS.
+	ConflictingCols []int
+
+	// Relaxations are bound changes that together make the model
+	// feasible, found by minimizing the total bound violation.
+	Relaxations []BoundRelaxation
+}
+
+// BoundRelaxation suggests new bounds for a constraint or variable.
+type BoundRelaxation struct {
+	// Row is true for a constraint and false for a variable.
+	Row bool
+
+	// Index is the row or column index.
+	Index int
+
+	// Lower and Upper are the suggested bounds; only one differs from
+	// the model's bounds.
+	Lower float64
+	Upper float64
+}
+
+// DiagnoseInfeasibility solves the model and, if it is infeasible,
+// reports which constraints conflict and how bounds could be relaxed to
+// make it feasible. It returns a nil report if the model is not
+// infeasible.
+//
+// The conflict is found with HiGHS's IIS computation and the suggested
+// relaxations with its feasibility relaxation, which minimizes the sum of
+// all bound and constraint violations. Options are applied as in Solve.
+func (m *Model) DiagnoseInfeasibility(opts ...SolveOption) (*InfeasibilityReport, error) {
+	solver, err := NewSolver()
+	if err != nil {
+		return nil, err
+	}
+	defer solver.Close()
+
+	cfg := defaultSolveConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if err := cfg.apply(solver); err != nil {
+		return nil, err
+	}
+	if err := m.load(solver); err != nil {
+		return nil, err
+	}
+
+	sol, err := solver.Run()
+	if err != nil {
+		return nil, err
+	}
+	if !sol.IsInfeasible() {
+		return nil, nil
+	}
+
+	report := &InfeasibilityReport{}
+	if report.ConflictingRows, report.ConflictingCols, err = solver.getIIS(); err != nil {
+		return nil, err
+	}
+	if report.Relaxations, err = solver.feasibilityRelaxation(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// rowViolations measures how far each constraint of an infeasible model
+// is from its bounds at a least-infeasible point. It loads into the
+// solver the LP relaxation of the model extended with a nonnegative
+// elastic column for each finite row bound, absorbing that bound's
+// violation, and minimizes the sum of the elastic columns. Fixed values
+// are applied as in the original solve. It returns nil when no row is
+// violated at that point, because the column bounds or fixed values
+// conflict, making the elastic model infeasible, or because only
+// integrality makes the model infeasible.
+func (m *Model) rowViolations(solver *Solver, fixed map[int]float64) ([]float64, error) {
+	numCol := m.NumVars()
+	numRow := m.NumConstraints()
+	rowLower, err := expandSlice(numRow, m.RowLower, math.Inf(-1))
+	if err != nil {
+		return nil, newErrorMsg("Solve", "inconsistent RowLower length")
+	}
+	rowUpper, err := expandSlice(numRow, m.RowUpper, math.Inf(1))
+	if err != nil {
+		return nil, newErrorMsg("Solve", "inconsistent RowUpper length")
+	}
+
+	elastic := &Model{
+		ColLower:    append([]float64(nil), m.ColLower...),
+		ColUpper:    append([]float64(nil), m.ColUpper...),
+		RowLower:    rowLower,
+		RowUpper:    rowUpper,
+		ConstMatrix: append([]Nonzero(nil), m.ConstMatrix...),
+	}
+	if elastic.ColLower, err = expandSlice(numCol, elastic.ColLower, math.Inf(-1)); err != nil {
+		return nil, newErrorMsg("Solve", "inconsistent ColLower length")
+	}
+	if elastic.ColUpper, err = expandSlice(numCol, elastic.ColUpper, math.Inf(1)); err != nil {
+		return nil, newErrorMsg("Solve", "inconsistent ColUpper length")
+	}
+	elastic.ColCosts = make([]float64, numCol)
+
+	// Each finite lower bound gets a column raising the row activity and
+	// each finite upper bound one lowering it
+	type elasticCol struct{ row, col int }
+	var cols []elasticCol
+	for row := 0; row < numRow; row++ {
+		for _, sign := range [2]float64{1, -1} {
+			bound := rowLower[row]
+			if sign < 0 {
+				bound = rowUpper[row]
+			}
+			if !isFiniteBound(bound) {
+				continue
+			}
+			col := len(elastic.ColCosts)
+			elastic.ColCosts = append(elastic.ColCosts, 1)
+			elastic.ColLower = append(elastic.ColLower, 0)
+			elastic.ColUpper = append(elastic.ColUpper, math.Inf(1))
+			elastic.ConstMatrix = append(elastic.ConstMatrix, Nonzero{Row: row, Col: col, Val: sign})
+			cols = append(cols, elasticCol{row, col})
+		}
+	}
+
+	if err := elastic.load(solver); err != nil {
+		return nil, err
+	}
+	if err := elastic.fixValues(solver, fixed); err != nil {
+		return nil, err
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		return nil, err
+	}
+	if sol.IsInfeasible() {
+		return nil, nil
+	}
+	if !sol.IsOptimal() {
+		return nil, newErrorMsg("Solve", fmt.Sprintf("elastic model is %s", sol.Status))
+	}
+	if sol.Objective <= relaxationTol {
+		return nil, nil
+	}
+
+	violations := make([]float64, numRow)
+	for _, c := range cols {
+		violations[c.row] += math.Max(sol.ColValues[c.col], 0)
+	}
+	return violations, nil
+}
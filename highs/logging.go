@@ -0,0 +1,147 @@
+//go:build cgo && (linux || darwin) && (amd64 || arm64)
+
+package highs
+
+/*
+#include "highs_c_api.h"
+
+// gohighs_logOptions reads the options that decide whether HiGHS passes
+// log lines to the logging callback.
+static void gohighs_logOptions(void* highs, HighsInt* output_flag, HighsInt* log_to_console, HighsInt* has_log_file) {
+	char log_file[kHighsMaximumStringLength];
+	*output_flag = 0;
+	*log_to_console = 0;
+	log_file[0] = '\0';
+	Highs_getBoolOptionValue(highs, "output_flag", output_flag);
+	Highs_getBoolOptionValue(highs, "log_to_console", log_to_console);
+	Highs_getStringOptionValue(highs, "log_file", log_file);
+	*has_log_file = log_file[0] != '\0';
+}
+*/
+import "C"
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// The option names runLogged sets, allocated once so capturing a run's
+// log does not allocate.
+var (
+	cOutputFlag   = C.CString("output_flag")
+	cLogToConsole = C.CString("log_to_console")
+)
+
+// logTypeWarning is HighsLogType::kWarning, the log_type of warnings
+// passed to the logging callback.
+const logTypeWarning = 4
+
+// runLog collects the log of a single run. It is kept apart from the
+// Solver so the callback handle does not keep the Solver reachable.
+type runLog struct {
+	warnings        []string
+	forward         bool
+	writer          io.Writer
+	captureWarnings bool
+}
+
+// handle is the callbackHandler for kHighsCallbackLogging.
+func (l *runLog) handle(message *C.char, out *C.HighsCallbackDataOut, _ *C.HighsCallbackDataIn) {
+	if out.log_type != logTypeWarning && !l.forward && l.writer == nil {
+		return
+	}
+	// Copy the message: HiGHS owns it only for the duration of the call
+	msg := C.GoString(message)
+	if out.log_type == logTypeWarning {
+		trimmed := strings.TrimSpace(msg)
+		l.warnings = append(l.warnings, strings.TrimSpace(strings.TrimPrefix(trimmed, "WARNING:")))
+	}
+	if l.forward {
+		os.Stdout.WriteString(msg)
+	}
+	if l.writer != nil {
+		// Write errors drop the line rather than fail the solve
+		io.WriteString(l.writer, msg)
+	}
+}
+
+// initLog registers the logging callback on first use.
+func (s *Solver) initLog() error {
+	if s.log != nil {
+		return nil
+	}
+	s.log = &runLog{}
+	if err := s.installCallback(C.kHighsCallbackLogging, s.log.handle); err != nil {
+		s.log = nil
+		return err
+	}
+	return nil
+}
+
+// SetLogWriter sends the HiGHS log of each Run to w, one message per
+// Write, whether or not output_flag is set; console output still follows
+// the output options. Errors returned by w are ignored. The log is not
+// captured while the options direct it to a file, as WithSolveTrace
+// does. A nil w stops the forwarding.
+func (s *Solver) SetLogWriter(w io.Writer) error {
+	if err := s.initLog(); err != nil {
+		return err
+	}
+	s.log.writer = w
+	return nil
+}
+
+// SetWarningCapture sets whether each Run collects the warnings HiGHS
+// logs into Solution.Warnings, even when output is disabled. Capturing
+// makes HiGHS format every log line and pass it to Go, which costs time
+// in tight re-solve loops, so it is off by default for a Solver; the
+// Model solve functions turn it on. Warnings are not captured while a
+// log_file is set, as WithSolveTrace does, since HiGHS would then no
+// longer write the file.
+func (s *Solver) SetWarningCapture(enabled bool) error {
+	if err := s.initLog(); err != nil {
+		return err
+	}
+	s.log.captureWarnings = enabled
+	return nil
+}
+
+// runLogged calls Highs_run, collecting the warnings HiGHS logs into
+// s.log.warnings when they are captured or a log writer is set.
+//
+// HiGHS drops log lines before they reach the callback unless
+// output_flag is set and, with no log file, log_to_console is set too.
+// Either option that is off is turned on for the run only and restored
+// on return; while the callback is active nothing reaches the console.
+// A log file would get nothing, or be written although output is
+// disabled, so when log_file is set logging is left to HiGHS.
+func (s *Solver) runLogged() (Status, error) {
+	if err := s.initLog(); err != nil {
+		return StatusError, err
+	}
+	s.log.warnings = s.log.warnings[:0]
+	s.log.forward = false
+	if !s.log.captureWarnings && s.log.writer == nil {
+		return Status(C.Highs_run(s.ptr)), nil
+	}
+	var output, console, hasLogFile C.HighsInt
+	C.gohighs_logOptions(s.ptr, &output, &console, &hasLogFile)
+	if hasLogFile != 0 {
+		return Status(C.Highs_run(s.ptr)), nil
+	}
+
+	s.log.forward = output != 0 && console != 0
+	if output == 0 {
+		C.Highs_setBoolOptionValue(s.ptr, cOutputFlag, 1)
+		defer C.Highs_setBoolOptionValue(s.ptr, cOutputFlag, 0)
+	}
+	if console == 0 {
+		C.Highs_setBoolOptionValue(s.ptr, cLogToConsole, 1)
+		defer C.Highs_setBoolOptionValue(s.ptr, cLogToConsole, 0)
+	}
+	if err := newError("Run", Status(C.Highs_startCallback(s.ptr, C.kHighsCallbackLogging))); err != nil {
+		return StatusError, err
+	}
+	defer C.Highs_stopCallback(s.ptr, C.kHighsCallbackLogging)
+	return Status(C.Highs_run(s.ptr)), nil
+}
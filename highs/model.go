@@ -1,6 +1,18 @@
 package highs
 
-import "math"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"sort"
+	"strconv"
+)
 
 // Model represents a high-level optimization model.
 // It provides a convenient way to define LP, MIP, and QP problems
@@ -51,6 +63,78 @@ type Model struct {
 	// VarTypes specifies the type of each variable (continuous, integer, etc.).
 	// If empty, all variables are treated as continuous.
 	VarTypes []VariableType
+
+	// RowGroups optionally assigns each constraint to a named group
+	// (e.g. "capacity", "demand") for Solution.GroupReport. It does not
+	// affect the solve. Rows without a group may be left empty.
+	RowGroups []string
+
+	// ColNames optionally names each variable, e.g. for
+	// Solution.ColValueMap. Missing or empty names default to "C" followed
+	// by the column index, with a suffix such as "_2" if another variable
+	// already has that name. The names are passed to HiGHS, so they appear
+	// in model files written by Solver.WriteModel; names beyond NumVars
+	// are ignored.
+	ColNames []string
+
+	// RowNames optionally names each constraint, e.g. for
+	// Solution.RowActivityByName. Missing or empty names default to "R"
+	// followed by the row index, suffixed in the same way. Like ColNames,
+	// they are passed to HiGHS and names beyond NumConstraints are ignored.
+	RowNames []string
+
+	// hessianPos caches where AddQuadraticTerm finds each pair in Hessian.
+	hessianPos hessianPositions
+}
+
+// hessianPositions maps each (min(i, j), max(i, j)) pair to its entry in
+// a Hessian slice, remembering which slice it was built for so that
+// changes made to Hessian by other means are detected.
+type hessianPositions struct {
+	pos  map[[2]int]int
+	base *Nonzero
+	len  int
+}
+
+// lookup returns the position of the pair (i, j), i <= j, in hessian,
+// rebuilding the map if hessian is not the slice it was built for.
+func (p *hessianPositions) lookup(hessian []Nonzero, i, j int) (int, bool) {
+	if p.pos == nil || p.len != len(hessian) || (len(hessian) > 0 && p.base != &hessian[0]) {
+		p.rebuild(hessian)
+	}
+	k, ok := p.pos[[2]int{i, j}]
+	if ok && (k >= len(hessian) || !samePair(hessian[k], i, j)) {
+		// A copy of the Model shares the map; start afresh
+		p.rebuild(hessian)
+		k, ok = p.pos[[2]int{i, j}]
+	}
+	return k, ok
+}
+
+// rebuild indexes hessian, keeping the last of duplicate entries as
+// Solve does.
+func (p *hessianPositions) rebuild(hessian []Nonzero) {
+	p.pos = make(map[[2]int]int, len(hessian))
+	for k, nz := range hessian {
+		i, j := min(nz.Row, nz.Col), max(nz.Row, nz.Col)
+		p.pos[[2]int{i, j}] = k
+	}
+	p.sync(hessian)
+}
+
+// sync records hessian as the slice the map describes.
+func (p *hessianPositions) sync(hessian []Nonzero) {
+	p.len = len(hessian)
+	p.base = nil
+	if len(hessian) > 0 {
+		p.base = &hessian[0]
+	}
+}
+
+// samePair reports whether nz holds the pair (i, j), i <= j, in either
+// triangle.
+func samePair(nz Nonzero, i, j int) bool {
+	return min(nz.Row, nz.Col) == i && max(nz.Row, nz.Col) == j
 }
 
 // AddDenseRow adds a constraint to the model using a dense coefficient vector.
@@ -113,6 +197,156 @@ func (m *Model) AddGeRow(coeffs []float64, rhs float64) {
 	m.AddDenseRow(rhs, coeffs, math.Inf(1))
 }
 
+// AddRangeRow adds a ranged constraint: lower <= sum(coeffs * x) <= upper.
+// It is AddDenseRow with the bounds after the coefficients, in the order
+// they appear in the constraint's usual reading.
+func (m *Model) AddRangeRow(coeffs []float64, lower, upper float64) {
+	m.AddDenseRow(lower, coeffs, upper)
+}
+
+// AddEqRowSparse adds an equality constraint from sparse coefficients:
+// sum(vals[i] * x[cols[i]]) = rhs.
+func (m *Model) AddEqRowSparse(cols []int, vals []float64, rhs float64) {
+	m.AddSparseRow(rhs, cols, vals, rhs)
+}
+
+// AddLeRowSparse adds a less-than-or-equal constraint from sparse
+// coefficients: sum(vals[i] * x[cols[i]]) <= rhs.
+func (m *Model) AddLeRowSparse(cols []int, vals []float64, rhs float64) {
+	m.AddSparseRow(math.Inf(-1), cols, vals, rhs)
+}
+
+// AddGeRowSparse adds a greater-than-or-equal constraint from sparse
+// coefficients: sum(vals[i] * x[cols[i]]) >= rhs.
+func (m *Model) AddGeRowSparse(cols []int, vals []float64, rhs float64) {
+	m.AddSparseRow(rhs, cols, vals, math.Inf(1))
+}
+
+// AddSoftConstraint adds a constraint sum(coeffs * x) sense rhs that may
+// be violated at a cost of penalty per unit of violation. It appends a
+// nonnegative penalty variable with objective coefficient penalty (negated
+// when maximizing) that absorbs the violation: one for SenseLessEqual and
+// SenseGreaterEqual, and two for SenseEqual, one per direction. NumVars
+// grows by the number of penalty variables, which follow all existing
+// variables and whose values in the solution are the violation amounts.
+// The penalty should be nonnegative. Its sign is fixed from Maximize when
+// the constraint is added, so set Maximize first. An unknown sense is an
+// error and leaves the model unchanged.
+//
+// Example:
+//
+//	model.AddSoftConstraint([]float64{1.0, 1.0}, SenseGreaterEqual, 10.0, 5.0)
+//	// Adds constraint: x0 + x1 + s >= 10, s >= 0, with 5*s in the objective
+func (m *Model) AddSoftConstraint(coeffs []float64, sense Sense, rhs, penalty float64) error {
+	switch sense {
+	case SenseLessEqual, SenseGreaterEqual, SenseEqual:
+	default:
+		return newErrorMsg("AddSoftConstraint", fmt.Sprintf("unknown constraint sense %d", int(sense)))
+	}
+	cost := penalty
+	if m.Maximize {
+		cost = -penalty
+	}
+	row := append([]float64(nil), coeffs...)
+	for len(row) < m.NumVars() {
+		row = append(row, 0)
+	}
+	if sense != SenseGreaterEqual {
+		row = append(row, -1) // Surplus: lhs above rhs
+		m.addVar(len(row)-1, cost, 0, math.Inf(1))
+	}
+	if sense != SenseLessEqual {
+		row = append(row, 1) // Slack: lhs below rhs
+		m.addVar(len(row)-1, cost, 0, math.Inf(1))
+	}
+
+	switch sense {
+	case SenseLessEqual:
+		m.AddLeRow(row, rhs)
+	case SenseGreaterEqual:
+		m.AddGeRow(row, rhs)
+	case SenseEqual:
+		m.AddEqRow(row, rhs)
+	}
+	return nil
+}
+
+// addVar adds a continuous variable at index col, which must be at least
+// NumVars, padding the column slices of the preceding variables with
+// defaults.
+func (m *Model) addVar(col int, cost, lower, upper float64) {
+	pad := func(v []float64, fill float64) []float64 {
+		for len(v) < col {
+			v = append(v, fill)
+		}
+		return v
+	}
+	m.ColCosts = append(pad(m.ColCosts, 0), cost)
+	m.ColLower = append(pad(m.ColLower, math.Inf(-1)), lower)
+	m.ColUpper = append(pad(m.ColUpper, math.Inf(1)), upper)
+	if len(m.VarTypes) > 0 {
+		for len(m.VarTypes) < col {
+			m.VarTypes = append(m.VarTypes, Continuous)
+		}
+		m.VarTypes = append(m.VarTypes, Continuous)
+	}
+}
+
+// AddQuadraticTerm adds coeff * x_i * x_j to the objective, taking care
+// of the Hessian conventions: the objective holds 0.5 * x' * Hessian * x
+// with only the upper triangle of the symmetric Hessian stored. A square
+// term (i == j) is therefore stored as 2*coeff on the diagonal, and a
+// cross term as coeff at (min(i, j), max(i, j)), which stands for both
+// halves of the symmetric pair. Terms for the same pair accumulate in its
+// existing entry. Indices are not checked here; Validate and Solve reject
+// negative ones.
+//
+// Example:
+//
+//	model.AddQuadraticTerm(0, 0, 1.0)  // x0², stored as Hessian (0, 0) = 2
+//	model.AddQuadraticTerm(2, 0, -1.0) // -x0*x2, stored as Hessian (0, 2) = -1
+func (m *Model) AddQuadraticTerm(i, j int, coeff float64) {
+	if i > j {
+		i, j = j, i
+	}
+	val := coeff
+	if i == j {
+		val = 2 * coeff
+	}
+	if k, ok := m.hessianPos.lookup(m.Hessian, i, j); ok {
+		m.Hessian[k].Val += val
+		return
+	}
+	m.Hessian = append(m.Hessian, Nonzero{Row: i, Col: j, Val: val})
+	m.hessianPos.pos[[2]int{i, j}] = len(m.Hessian) - 1
+	m.hessianPos.sync(m.Hessian)
+}
+
+// SetObjectiveSparse replaces ColCosts with the costs in the sparse map
+// from column index to coefficient; all other columns get cost zero.
+// ColCosts is sized to cover both the largest index in costs and the
+// current number of variables. It returns an error, leaving ColCosts
+// unchanged, if an index is negative.
+//
+// Example:
+//
+//	model.SetObjectiveSparse(map[int]float64{0: 1.0, 999: -2.0})
+//	// Objective: x0 - 2*x999
+func (m *Model) SetObjectiveSparse(costs map[int]float64) error {
+	numCol := m.NumVars()
+	for col := range costs {
+		if col < 0 {
+			return newErrorMsg("SetObjectiveSparse", fmt.Sprintf("negative column index %d", col))
+		}
+		numCol = max(numCol, col+1)
+	}
+	m.ColCosts = make([]float64, numCol)
+	for col, cost := range costs {
+		m.ColCosts[col] = cost
+	}
+	return nil
+}
+
 // NumVars returns the number of variables in the model.
 func (m *Model) NumVars() int {
 	maxCol := -1
@@ -158,6 +392,183 @@ func (m *Model) NumConstraints() int {
 	return maxRow + 1
 }
 
+// Validate checks that the model is structurally consistent, reporting
+// the first problem found with the offending field: ColCosts, ColLower,
+// ColUpper, and VarTypes must be empty or have length NumVars, RowLower
+// and RowUpper must be empty or have length NumConstraints, ConstMatrix
+// indices must be nonnegative, and Hessian entries must lie in the upper
+// triangle of a NumVars by NumVars matrix, with no off-diagonal term
+// supplied both as (i, j) and as (j, i). Solve runs the same checks
+// piecemeal while loading the model; Validate reports them up front, and
+// WithValidation makes Solve call it first.
+func (m *Model) Validate() error {
+	numCol := m.NumVars()
+	numRow := m.NumConstraints()
+	for _, f := range []struct {
+		name   string
+		length int
+		want   int
+		what   string
+	}{
+		{"ColCosts", len(m.ColCosts), numCol, "NumVars"},
+		{"ColLower", len(m.ColLower), numCol, "NumVars"},
+		{"ColUpper", len(m.ColUpper), numCol, "NumVars"},
+		{"VarTypes", len(m.VarTypes), numCol, "NumVars"},
+		{"RowLower", len(m.RowLower), numRow, "NumConstraints"},
+		{"RowUpper", len(m.RowUpper), numRow, "NumConstraints"},
+	} {
+		if f.length != 0 && f.length != f.want {
+			return newErrorMsg("Validate", fmt.Sprintf("%s has length %d, expected 0 or %s = %d", f.name, f.length, f.what, f.want))
+		}
+	}
+	for i, nz := range m.ConstMatrix {
+		if nz.Row < 0 || nz.Col < 0 {
+			return newErrorMsg("Validate", fmt.Sprintf("ConstMatrix[%d] has negative index (%d, %d)", i, nz.Row, nz.Col))
+		}
+	}
+	if msg := transposedHessianError(m.Hessian); msg != "" {
+		return newErrorMsg("Validate", msg)
+	}
+	for i, nz := range m.Hessian {
+		if nz.Row < 0 || nz.Col < 0 || nz.Row >= numCol || nz.Col >= numCol {
+			return newErrorMsg("Validate", fmt.Sprintf("Hessian[%d] index (%d, %d) outside the %d x %d matrix", i, nz.Row, nz.Col, numCol, numCol))
+		}
+		if nz.Row > nz.Col {
+			return newErrorMsg("Validate", fmt.Sprintf("Hessian[%d] at (%d, %d) is below the diagonal; the Hessian must be upper triangular", i, nz.Row, nz.Col))
+		}
+	}
+	return nil
+}
+
+// transposedHessianError describes the first off-diagonal Hessian entry
+// supplied both as (i, j) and as its transpose (j, i), or returns an
+// empty string if there is none. Only the upper triangle is stored, so
+// such a pair usually means the same term was entered twice.
+func transposedHessianError(hessian []Nonzero) string {
+	seen := make(map[[2]int]bool, len(hessian))
+	for _, nz := range hessian {
+		if nz.Row == nz.Col {
+			continue
+		}
+		if seen[[2]int{nz.Col, nz.Row}] {
+			i, j := min(nz.Row, nz.Col), max(nz.Row, nz.Col)
+			return fmt.Sprintf("Hessian has entries at both (%d, %d) and (%d, %d); "+
+				"consolidate them into a single upper-triangular entry (%d, %d)", i, j, j, i, i, j)
+		}
+		seen[[2]int{nz.Row, nz.Col}] = true
+	}
+	return ""
+}
+
+// PerturbCosts adds small random noise to the objective coefficients,
+// which can help break ties between degenerate optima. Each cost c is
+// shifted by a uniform amount in [-scale, scale] * max(1, |c|).
+//
+// The caller supplies the random source so perturbations are reproducible
+// and safe to run in parallel. ColCosts is replaced with a new slice of
+// length NumVars, so models sharing the original slice are unaffected.
+func (m *Model) PerturbCosts(rng *rand.Rand, scale float64) {
+	costs := make([]float64, m.NumVars())
+	copy(costs, m.ColCosts)
+	for i, c := range costs {
+		costs[i] = c + scale*(2*rng.Float64()-1)*math.Max(1, math.Abs(c))
+	}
+	m.ColCosts = costs
+}
+
+// ReadModelFile reads a model from a file (LP, MPS, or other format
+// supported by HiGHS) and returns it as a Model.
+//
+// For MPS files the objective row (the first N row) populates ColCosts
+// and is not treated as a constraint.
+func ReadModelFile(filename string) (*Model, error) {
+	solver, err := NewSolver()
+	if err != nil {
+		return nil, err
+	}
+	defer solver.Close()
+
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		return nil, err
+	}
+	if err := solver.ReadModel(filename); err != nil {
+		return nil, err
+	}
+	return solver.GetModel()
+}
+
+// Hash returns a hex-encoded SHA-256 digest of the model.
+//
+// The model is canonicalized first, in the same way as ModelDiff, so two
+// models that ModelDiff reports as equal have the same hash.
+func (m *Model) Hash() string {
+	h := sha256.New()
+	var buf [8]byte
+	writeUint := func(v uint64) {
+		binary.LittleEndian.PutUint64(buf[:], v)
+		h.Write(buf[:])
+	}
+	writeFloat := func(v float64) {
+		writeUint(math.Float64bits(v))
+	}
+	writeSlice := func(n int, slice []float64, fillValue float64) {
+		for i := 0; i < n; i++ {
+			if i < len(slice) {
+				writeFloat(slice[i])
+			} else {
+				writeFloat(fillValue)
+			}
+		}
+	}
+	writeMatrix := func(nz []Nonzero) {
+		entries := canonicalEntries(nz)
+		keys := make([][2]int, 0, len(entries))
+		for k := range entries {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i][0] != keys[j][0] {
+				return keys[i][0] < keys[j][0]
+			}
+			return keys[i][1] < keys[j][1]
+		})
+		writeUint(uint64(len(keys)))
+		for _, k := range keys {
+			writeUint(uint64(k[0]))
+			writeUint(uint64(k[1]))
+			writeFloat(entries[k])
+		}
+	}
+
+	numCol := m.NumVars()
+	numRow := m.NumConstraints()
+
+	if m.Maximize {
+		writeUint(1)
+	} else {
+		writeUint(0)
+	}
+	writeFloat(m.Offset)
+	writeUint(uint64(numCol))
+	writeUint(uint64(numRow))
+	writeSlice(numCol, m.ColCosts, 0.0)
+	writeSlice(numCol, m.ColLower, math.Inf(-1))
+	writeSlice(numCol, m.ColUpper, math.Inf(1))
+	writeSlice(numRow, m.RowLower, math.Inf(-1))
+	writeSlice(numRow, m.RowUpper, math.Inf(1))
+	for i := 0; i < numCol; i++ {
+		if i < len(m.VarTypes) {
+			writeUint(uint64(m.VarTypes[i]))
+		} else {
+			writeUint(uint64(Continuous))
+		}
+	}
+	writeMatrix(m.ConstMatrix)
+	writeMatrix(m.Hessian)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // Solve builds and solves the model, returning the solution.
 //
 // Options can be set using SolveOptions:
@@ -168,6 +579,30 @@ func (m *Model) NumConstraints() int {
 //		highs.WithOutput(false),
 //	)
 func (m *Model) Solve(opts ...SolveOption) (*Solution, error) {
+	return m.solve(context.Background(), opts)
+}
+
+// SolveContext is like Solve but stops the solve when ctx is done. HiGHS
+// cannot be stopped at an arbitrary point, so cancellation is checked in
+// its simplex, IPM, and MIP callbacks and takes effect at the next
+// callback; phases without callbacks, such as presolve, run to completion
+// first. A time limit set with WithTimeLimit still applies as a fallback.
+//
+// On cancellation it returns an error wrapping both ErrCanceled and
+// ctx.Err(), together with the solution found so far, which for a MIP
+// holds the best incumbent if Populated is set.
+func (m *Model) SolveContext(ctx context.Context, opts ...SolveOption) (*Solution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCanceled, err)
+	}
+	sol, err := m.solve(ctx, opts)
+	if err == nil && ctx.Err() != nil && sol.Status == ModelStatusInterrupt {
+		return sol, fmt.Errorf("%w: %w", ErrCanceled, ctx.Err())
+	}
+	return sol, err
+}
+
+func (m *Model) solve(ctx context.Context, opts []SolveOption) (*Solution, error) {
 	solver, err := NewSolver()
 	if err != nil {
 		return nil, err
@@ -184,42 +619,442 @@ func (m *Model) Solve(opts ...SolveOption) (*Solution, error) {
 		return nil, err
 	}
 
-	// Determine dimensions
-	numCol := m.NumVars()
+	if cfg.validate {
+		if err := m.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var fingerprint string
+	if cfg.optionsFingerprint {
+		if fingerprint, err = solver.OptionsFingerprint(); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.NumVars() == 0 {
+		sol := m.solveEmpty()
+		sol.Info.OptionsFingerprint = fingerprint
+		return sol, nil
+	}
+
+	if err := m.loadFormat(solver, cfg.matrixFormat); err != nil {
+		return nil, err
+	}
+	if err := m.fixValues(solver, cfg.fixedValues); err != nil {
+		return nil, err
+	}
+	if cfg.initialBasis {
+		if err := solver.SetBasis(cfg.colBasis, cfg.rowBasis); err != nil {
+			return nil, err
+		}
+	}
+	if prev := cfg.warmStart; prev != nil {
+		if len(prev.ColBasis) > 0 || len(prev.RowBasis) > 0 {
+			err = solver.SetBasis(prev.ColBasis, prev.RowBasis)
+		} else if prev.Populated {
+			err = solver.setSolution(prev.ColValues)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var relaxation float64
+	if cfg.rootRelaxation {
+		if relaxation, err = solveRelaxation(solver, m.Maximize); err != nil {
+			return nil, err
+		}
+	}
+
+	var progress *progressReporter
+	if cfg.progress != nil {
+		progress = &progressReporter{report: cfg.progress}
+		if err := solver.setProgressReporter(progress); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.mipProgress != nil {
+		if err := solver.setMIPProgress(cfg.mipProgress); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.stopAtFirstFeasible {
+		if err := solver.setStopAtFirstFeasible(); err != nil {
+			return nil, err
+		}
+	}
+
+	var pool []PoolEntry
+	if cfg.collectSolutions > 0 {
+		if err := solver.setSolutionCollector(cfg.collectSolutions, &pool); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.interruptOn != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-cfg.interruptOn:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	if ctx.Done() != nil {
+		if err := solver.setContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Solve
+	sol, err := solver.Run()
+	if progress != nil {
+		progress.finish(solver, sol, m.Maximize)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The handlers above belong to the main solve; follow-up solves must
+	// not report progress, collect solutions or be stopped early
+	if err := solver.stopCallbacks(); err != nil {
+		return nil, err
+	}
+	if sol.Status == ModelStatusUnboundedOrInfeasible {
+		if err := m.classifyUnboundedOrInfeasible(solver, sol); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.rowViolations && sol.Status == ModelStatusInfeasible {
+		if sol.RowViolations, err = m.rowViolations(solver, cfg.fixedValues); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.secondaryObjective != nil {
+		if sol, err = m.solveSecondary(solver, sol, cfg.secondaryObjective); err != nil {
+			return nil, err
+		}
+	}
+	sol.Info.OptionsFingerprint = fingerprint
+	sol.Pool = pool
+	if cfg.rootRelaxation {
+		sol.Info.RootRelaxationObjective = relaxation
+	}
+	if cfg.numericalWarnings {
+		sol.Warnings = append(sol.Warnings, m.numericalWarnings(maxCoefficientRange)...)
+	}
+	if cfg.zeroThreshold > 0 {
+		sol.Clean(cfg.zeroThreshold)
+	}
+	return sol, nil
+}
+
+// solveEmpty solves a model without variables, such as one whose
+// variables were all removed by EliminateFixedVariables: it is optimal
+// with objective Offset unless a constraint excludes zero activity.
+func (m *Model) solveEmpty() *Solution {
 	numRow := m.NumConstraints()
+	for row := 0; row < numRow; row++ {
+		if (row < len(m.RowLower) && m.RowLower[row] > 0) || (row < len(m.RowUpper) && m.RowUpper[row] < 0) {
+			return &Solution{Status: ModelStatusInfeasible}
+		}
+	}
+	return &Solution{Status: ModelStatusOptimal, Objective: m.Offset}
+}
+
+// secondaryObjectiveTol is the relative slack allowed on the primary
+// objective when optimizing a secondary objective.
+const secondaryObjectiveTol = 1e-9
+
+// solveSecondary re-solves the loaded model, restricted to the optimal
+// face of the primary objective, minimizing the secondary costs. It
+// returns the primary solution unchanged if it is not optimal or the
+// secondary solve does not reach optimality.
+func (m *Model) solveSecondary(solver *Solver, primary *Solution, costs []float64) (*Solution, error) {
+	numCol := m.NumVars()
+	if len(costs) > numCol {
+		return nil, newErrorMsg("WithSecondaryObjective", fmt.Sprintf("%d costs for %d variables", len(costs), numCol))
+	}
+	if len(m.Hessian) > 0 {
+		return nil, newErrorMsg("WithSecondaryObjective", "not supported for quadratic objectives")
+	}
+	if !primary.IsOptimal() {
+		return primary, nil
+	}
+
+	// Keep the primary objective at its optimum, up to a small tolerance
+	var index []int
+	var value []float64
+	for col, c := range m.ColCosts {
+		if c != 0 {
+			index = append(index, col)
+			value = append(value, c)
+		}
+	}
+	optimum := primary.Objective - m.Offset
+	slack := secondaryObjectiveTol * math.Max(1, math.Abs(optimum))
+	lower, upper := math.Inf(-1), optimum+slack
+	if m.Maximize {
+		lower, upper = optimum-slack, math.Inf(1)
+	}
+	numRow := solver.NumRow()
+	if err := solver.AddRow(lower, upper, index, value); err != nil {
+		return nil, err
+	}
 
-	if numCol == 0 {
-		return &Solution{Status: ModelStatusOptimal}, nil
+	secondary := make([]float64, numCol)
+	copy(secondary, costs)
+	if err := solver.SetColCosts(secondary); err != nil {
+		return nil, err
+	}
+	if err := solver.SetMaximize(false); err != nil {
+		return nil, err
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		return nil, err
+	}
+	if !sol.IsOptimal() {
+		return primary, nil
+	}
+
+	// Report the primary objective and drop the objective row
+	sol.Objective = m.Offset
+	for i, col := range index {
+		sol.Objective += value[i] * sol.ColValues[col]
+	}
+	sol.RowValues = sol.RowValues[:numRow]
+	sol.RowDuals = sol.RowDuals[:numRow]
+	if len(sol.RowBasis) > numRow {
+		sol.RowBasis = sol.RowBasis[:numRow]
+	}
+	sol.Warnings = primary.Warnings
+	return sol, nil
+}
+
+// solveRelaxation solves the LP relaxation of the loaded model and then
+// restores its integrality, returning the relaxation objective.
+func solveRelaxation(solver *Solver, maximize bool) (float64, error) {
+	varTypes, err := solver.Integralities()
+	if err != nil {
+		return 0, err
+	}
+	if err := solver.AllContinuous(); err != nil {
+		return 0, err
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		return 0, err
+	}
+	if err := solver.SetIntegrality(varTypes); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case sol.IsOptimal():
+		return sol.Objective, nil
+	case sol.IsUnbounded():
+		if maximize {
+			return math.Inf(1), nil
+		}
+		return math.Inf(-1), nil
+	default:
+		return math.NaN(), nil
+	}
+}
+
+// classifyUnboundedOrInfeasible resolves a MIP's UnboundedOrInfeasible
+// status. An infeasible LP relaxation makes the MIP infeasible. An
+// unbounded one makes it unbounded if it has any feasible point, which a
+// solve with a zero objective decides. The classified status replaces
+// sol.Status, the original is kept in sol.StatusClassifiedFrom, and a
+// warning records how it was found. LPs are left alone, since HiGHS
+// classifies them itself. The probing solves run without the callbacks
+// of the main solve, which must be stopped beforehand, and the solution
+// they leave in the solver is cleared.
+func (m *Model) classifyUnboundedOrInfeasible(solver *Solver, sol *Solution) error {
+	varTypes, err := solver.Integralities()
+	if err != nil {
+		return err
+	}
+	mip := false
+	for _, t := range varTypes {
+		mip = mip || t != Continuous
+	}
+	if !mip {
+		return nil
+	}
+
+	if err := solver.AllContinuous(); err != nil {
+		return err
+	}
+	relaxed, err := solver.Run()
+	if err != nil {
+		return err
+	}
+	if err := solver.SetIntegrality(varTypes); err != nil {
+		return err
+	}
+	switch relaxed.Status {
+	case ModelStatusInfeasible:
+		sol.StatusClassifiedFrom, sol.Status = sol.Status, ModelStatusInfeasible
+		sol.Warnings = append(sol.Warnings, "HiGHS reported UnboundedOrInfeasible; classified as Infeasible since the LP relaxation is infeasible")
+		return solver.ClearSolver()
+	case ModelStatusUnbounded:
+	default:
+		return solver.ClearSolver()
+	}
+
+	costs, err := expandSlice(len(varTypes), m.ColCosts, 0)
+	if err != nil {
+		return err
+	}
+	if err := solver.SetColCosts(make([]float64, len(costs))); err != nil {
+		return err
+	}
+	feasible, err := solver.Run()
+	if err != nil {
+		return err
+	}
+	if err := solver.SetColCosts(costs); err != nil {
+		return err
+	}
+	switch feasible.Status {
+	case ModelStatusOptimal:
+		sol.StatusClassifiedFrom, sol.Status = sol.Status, ModelStatusUnbounded
+		sol.Warnings = append(sol.Warnings, "HiGHS reported UnboundedOrInfeasible; classified as Unbounded since the LP relaxation is unbounded and the MIP is feasible")
+	case ModelStatusInfeasible:
+		sol.StatusClassifiedFrom, sol.Status = sol.Status, ModelStatusInfeasible
+		sol.Warnings = append(sol.Warnings, "HiGHS reported UnboundedOrInfeasible; classified as Infeasible since the MIP has no feasible point")
+	}
+	return solver.ClearSolver()
+}
+
+// fixValues fixes columns to the given values by setting both bounds,
+// after checking that each value lies within the column's model bounds.
+func (m *Model) fixValues(solver *Solver, values map[int]float64) error {
+	cols := make([]int, 0, len(values))
+	for col := range values {
+		cols = append(cols, col)
+	}
+	sort.Ints(cols)
+
+	numCol := m.NumVars()
+	for _, col := range cols {
+		v := values[col]
+		if col < 0 || col >= numCol {
+			return newErrorMsg("WithFixedValues", fmt.Sprintf("column index %d out of range [0, %d)", col, numCol))
+		}
+		lower, upper := math.Inf(-1), math.Inf(1)
+		if col < len(m.ColLower) {
+			lower = m.ColLower[col]
+		}
+		if col < len(m.ColUpper) {
+			upper = m.ColUpper[col]
+		}
+		if math.IsNaN(v) || v < lower || v > upper {
+			return newErrorMsg("WithFixedValues", fmt.Sprintf("value %g for column %d outside bounds [%g, %g]", v, col, lower, upper))
+		}
+		if err := solver.SetColBounds(col, v, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxCoefficientRange is the ratio between the largest and smallest
+// nonzero coefficient magnitudes above which WithNumericalWarnings warns.
+const maxCoefficientRange = 1e12
+
+// numericalWarnings reports coefficient dynamic ranges in the objective
+// and constraint matrix that exceed limit.
+func (m *Model) numericalWarnings(limit float64) []string {
+	costMin, costMax := math.Inf(1), 0.0
+	for _, c := range m.ColCosts {
+		if c != 0 {
+			costMin = math.Min(costMin, math.Abs(c))
+			costMax = math.Max(costMax, math.Abs(c))
+		}
+	}
+	matrixMin, matrixMax := math.Inf(1), 0.0
+	for _, nz := range m.ConstMatrix {
+		if nz.Val != 0 {
+			matrixMin = math.Min(matrixMin, math.Abs(nz.Val))
+			matrixMax = math.Max(matrixMax, math.Abs(nz.Val))
+		}
 	}
 
+	var warnings []string
+	if costMax/costMin > limit {
+		warnings = append(warnings, fmt.Sprintf(
+			"ColCosts dynamic range %.1e exceeds %.0e ([%g, %g])", costMax/costMin, limit, costMin, costMax))
+	}
+	if matrixMax/matrixMin > limit {
+		warnings = append(warnings, fmt.Sprintf(
+			"ConstMatrix dynamic range %.1e exceeds %.0e ([%g, %g])", matrixMax/matrixMin, limit, matrixMin, matrixMax))
+	}
+	if len(warnings) == 0 {
+		lo, hi := math.Min(costMin, matrixMin), math.Max(costMax, matrixMax)
+		if hi/lo > limit {
+			warnings = append(warnings, fmt.Sprintf(
+				"combined ColCosts and ConstMatrix dynamic range %.1e exceeds %.0e ([%g, %g])", hi/lo, limit, lo, hi))
+		}
+	}
+	return warnings
+}
+
+// load passes the model to the solver, including the Hessian for QPs.
+func (m *Model) load(solver *Solver) error {
+	return m.loadFormat(solver, MatrixFormatRowwise)
+}
+
+// loadFormat is load with the constraint matrix passed in the given format.
+func (m *Model) loadFormat(solver *Solver, format MatrixFormat) error {
+	// Determine dimensions
+	numCol := m.NumVars()
+	numRow := m.NumConstraints()
+
 	// Prepare column data with defaults
 	colCosts, err := expandSlice(numCol, m.ColCosts, 0.0)
 	if err != nil {
-		return nil, newErrorMsg("Solve", "inconsistent ColCosts length")
+		return newErrorMsg("Solve", "inconsistent ColCosts length")
 	}
 	colLower, err := expandSlice(numCol, m.ColLower, math.Inf(-1))
 	if err != nil {
-		return nil, newErrorMsg("Solve", "inconsistent ColLower length")
+		return newErrorMsg("Solve", "inconsistent ColLower length")
 	}
 	colUpper, err := expandSlice(numCol, m.ColUpper, math.Inf(1))
 	if err != nil {
-		return nil, newErrorMsg("Solve", "inconsistent ColUpper length")
+		return newErrorMsg("Solve", "inconsistent ColUpper length")
 	}
 
 	// Prepare row data with defaults
 	rowLower, err := expandSlice(numRow, m.RowLower, math.Inf(-1))
 	if err != nil {
-		return nil, newErrorMsg("Solve", "inconsistent RowLower length")
+		return newErrorMsg("Solve", "inconsistent RowLower length")
 	}
 	rowUpper, err := expandSlice(numRow, m.RowUpper, math.Inf(1))
 	if err != nil {
-		return nil, newErrorMsg("Solve", "inconsistent RowUpper length")
+		return newErrorMsg("Solve", "inconsistent RowUpper length")
 	}
 
-	// Convert constraint matrix to CSR format
-	aStart, aIndex, aValue, err := nonzerosToCSR(m.ConstMatrix, false)
+	// Convert constraint matrix to the requested compressed format
+	var aStart, aIndex []int
+	var aValue []float64
+	if format == MatrixFormatColwise {
+		aStart, aIndex, aValue, err = nonzerosToCSC(m.ConstMatrix, numCol)
+	} else {
+		aStart, aIndex, aValue, err = nonzerosToCSR(m.ConstMatrix, numRow, false)
+	}
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Prepare variable types
@@ -231,7 +1066,7 @@ func (m *Model) Solve(opts ...SolveOption) (*Solution, error) {
 	}
 
 	// Pass the model
-	err = solver.PassModel(
+	err = solver.passModel(format,
 		numCol, numRow,
 		colCosts, colLower, colUpper,
 		rowLower, rowUpper,
@@ -241,22 +1076,62 @@ func (m *Model) Solve(opts ...SolveOption) (*Solution, error) {
 		m.Offset,
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Add Hessian for QP if present
 	if len(m.Hessian) > 0 {
-		hStart, hIndex, hValue, err := nonzerosToCSR(m.Hessian, true)
+		if msg := transposedHessianError(m.Hessian); msg != "" {
+			return newErrorMsg("Solve", msg)
+		}
+		hStart, hIndex, hValue, err := nonzerosToCSR(m.Hessian, numCol, true)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if err := solver.PassHessian(numCol, hStart, hIndex, hValue); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	// Solve
-	return solver.Run()
+	// Pass names, filling in defaults for any that are missing
+	if len(m.ColNames) > 0 {
+		if err := solver.SetColNames(defaultNames(m.ColNames, numCol, "C"), false); err != nil {
+			return err
+		}
+	}
+	if len(m.RowNames) > 0 {
+		if err := solver.SetRowNames(defaultNames(m.RowNames, numRow, "R"), false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// defaultNames returns n names taken from names, using prefix followed by
+// the index for names that are missing or empty. A generated name that is
+// already taken, such as "C1" given to another column, gets the smallest
+// free suffix "_2", "_3", ... instead, so it never shadows a given name.
+func defaultNames(names []string, n int, prefix string) []string {
+	out := make([]string, n)
+	taken := make(map[string]bool)
+	for i := 0; i < n && i < len(names); i++ {
+		taken[names[i]] = true
+	}
+	for i := range out {
+		if i < len(names) && names[i] != "" {
+			out[i] = names[i]
+			continue
+		}
+		name := prefix + strconv.Itoa(i)
+		key := name
+		for k := 2; taken[key]; k++ {
+			key = name + "_" + strconv.Itoa(k)
+		}
+		taken[key] = true
+		out[i] = key
+	}
+	return out
 }
 
 // SolveOption configures the solver behavior.
@@ -273,6 +1148,34 @@ type solveConfig struct {
 	extraInt    map[string]int
 	extraFloat  map[string]float64
 	extraString map[string]string
+
+	mipHeuristicEffort  *float64
+	mipDetectSymmetry   *bool
+	mipMaxImprovingSols *int
+
+	numericalWarnings   bool
+	validate            bool
+	matrixFormat        MatrixFormat
+	fixedValues         map[int]float64
+	rootRelaxation      bool
+	optionsFingerprint  bool
+	progress            func(Progress)
+	mipProgress         func(MIPProgress)
+	stopAtFirstFeasible bool
+	collectSolutions    int
+	rowViolations       bool
+	interruptOn         <-chan os.Signal
+	secondaryObjective  []float64
+	tracePath           string
+	zeroThreshold       float64
+	initialBasis        bool
+	colBasis            []BasisStatus
+	rowBasis            []BasisStatus
+	warmStart           *Solution
+	logWriter           io.Writer
+
+	// err records an invalid option value, reported when the config is applied.
+	err error
 }
 
 func defaultSolveConfig() *solveConfig {
@@ -285,6 +1188,41 @@ func defaultSolveConfig() *solveConfig {
 }
 
 func (c *solveConfig) apply(s *Solver) error {
+	if err := c.applyOptions(s); err != nil {
+		return err
+	}
+	if err := s.SetWarningCapture(true); err != nil {
+		return err
+	}
+	if c.logWriter != nil {
+		if err := s.SetLogWriter(c.logWriter); err != nil {
+			return err
+		}
+	}
+	if c.tracePath != "" {
+		// Applied last so the trace is written even if output was disabled
+		if err := s.SetStringOption("log_file", c.tracePath); err != nil {
+			return err
+		}
+		if err := s.SetIntOption("log_dev_level", traceLogDevLevel); err != nil {
+			return err
+		}
+		if err := s.SetBoolOption("log_to_console", false); err != nil {
+			return err
+		}
+		if err := s.SetBoolOption("output_flag", true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyOptions sets the HiGHS options of the config on s, leaving out
+// where the log goes, so that it has no effect outside the solver.
+func (c *solveConfig) applyOptions(s *Solver) error {
+	if c.err != nil {
+		return c.err
+	}
 	if c.output != nil {
 		if err := s.SetBoolOption("output_flag", *c.output); err != nil {
 			return err
@@ -315,6 +1253,21 @@ func (c *solveConfig) apply(s *Solver) error {
 			return err
 		}
 	}
+	if c.mipHeuristicEffort != nil {
+		if err := s.SetFloatOption("mip_heuristic_effort", *c.mipHeuristicEffort); err != nil {
+			return err
+		}
+	}
+	if c.mipDetectSymmetry != nil {
+		if err := s.SetBoolOption("mip_detect_symmetry", *c.mipDetectSymmetry); err != nil {
+			return err
+		}
+	}
+	if c.mipMaxImprovingSols != nil {
+		if err := s.SetIntOption("mip_max_improving_sols", *c.mipMaxImprovingSols); err != nil {
+			return err
+		}
+	}
 	for k, v := range c.extraBool {
 		if err := s.SetBoolOption(k, v); err != nil {
 			return err
@@ -380,6 +1333,234 @@ func WithPresolve(mode string) SolveOption {
 	}
 }
 
+// WithMIPHeuristicEffort sets the proportion of MIP effort spent on
+// primal heuristics. The value must be in [0, 1].
+func WithMIPHeuristicEffort(effort float64) SolveOption {
+	return func(c *solveConfig) {
+		if effort < 0 || effort > 1 {
+			c.err = newErrorMsg("WithMIPHeuristicEffort", "effort must be in [0, 1]")
+			return
+		}
+		c.mipHeuristicEffort = &effort
+	}
+}
+
+// WithMIPDetectSymmetry enables or disables symmetry detection in the MIP solver.
+func WithMIPDetectSymmetry(enabled bool) SolveOption {
+	return func(c *solveConfig) {
+		c.mipDetectSymmetry = &enabled
+	}
+}
+
+// WithMIPMaxImprovingSols sets the number of improving MIP solutions
+// after which the solve terminates. The value must be at least 1.
+func WithMIPMaxImprovingSols(n int) SolveOption {
+	return func(c *solveConfig) {
+		if n < 1 {
+			c.err = newErrorMsg("WithMIPMaxImprovingSols", "count must be at least 1")
+			return
+		}
+		c.mipMaxImprovingSols = &n
+	}
+}
+
+// WithCollectSolutions collects up to max distinct feasible solutions of
+// a MIP solve into Solution.Pool as HiGHS finds them, independently of how
+// many solutions HiGHS itself retains. Every solution the MIP solver
+// accepts is reported, not only improving ones, so the pool can hold
+// alternative optima, although HiGHS does not search for them. Only the
+// main solve contributes, not the follow-up solves of other options. The
+// value must be at least 1.
+func WithCollectSolutions(max int) SolveOption {
+	return func(c *solveConfig) {
+		if max < 1 {
+			c.err = newErrorMsg("WithCollectSolutions", "max must be at least 1")
+			return
+		}
+		c.collectSolutions = max
+	}
+}
+
+// WithStopAtFirstFeasible stops a MIP solve as soon as the first feasible
+// integer solution is found, for when any feasible answer suffices. The
+// solution is then returned with ModelStatusInterrupt rather than
+// ModelStatusOptimal. HiGHS only checks for interrupts between solver
+// phases, so a few better solutions found in the same phase, typically
+// by the root heuristics, may replace the first before the solve stops.
+func WithStopAtFirstFeasible() SolveOption {
+	return func(c *solveConfig) {
+		c.stopAtFirstFeasible = true
+	}
+}
+
+// WithInterruptOn stops the solve when a signal arrives on signals, for
+// example one registered with signal.Notify for os.Interrupt so that
+// Ctrl-C in a command-line tool ends a long solve. The solution found so
+// far is returned with ModelStatusInterrupt and no error; for a MIP it
+// holds the best incumbent if Populated is set. The interrupt is checked
+// as in SolveContext. A signal is only received while the solve runs.
+func WithInterruptOn(signals <-chan os.Signal) SolveOption {
+	return func(c *solveConfig) {
+		c.interruptOn = signals
+	}
+}
+
+// WithRowViolations quantifies the infeasibility of a model that turns
+// out to be infeasible: Solution.RowViolations then holds, for each
+// constraint, how far its activity lies outside its bounds at a point
+// minimizing the total violation. That point is found by an elastic LP
+// that keeps the column bounds but drops integrality, a lighter
+// diagnostic than DiagnoseInfeasibility. When several points minimize
+// the total violation, which constraints carry it is arbitrary.
+// RowViolations stays nil when the rows are not to blame: when the column
+// bounds or fixed values conflict, or when only integrality makes the
+// model infeasible.
+func WithRowViolations() SolveOption {
+	return func(c *solveConfig) {
+		c.rowViolations = true
+	}
+}
+
+// WithNumericalWarnings enables a scan of the model's objective and
+// constraint coefficients once the solve has finished. When their dynamic
+// range exceeds 1e12, which often leads to imprecise results, a warning is
+// added to Solution.Warnings.
+func WithNumericalWarnings(enabled bool) SolveOption {
+	return func(c *solveConfig) {
+		c.numericalWarnings = enabled
+	}
+}
+
+// WithValidation makes Solve check the model with Model.Validate before
+// loading it, so that an inconsistent model fails with an error naming
+// the offending field.
+func WithValidation(enabled bool) SolveOption {
+	return func(c *solveConfig) {
+		c.validate = enabled
+	}
+}
+
+// WithMatrixFormat sets how the constraint matrix is passed to HiGHS.
+//
+// Rowwise is the default: BenchmarkMatrixFormat shows it loading as fast
+// or faster than Colwise for both tall-skinny and short-wide matrices.
+func WithMatrixFormat(format MatrixFormat) SolveOption {
+	return func(c *solveConfig) {
+		c.matrixFormat = format
+	}
+}
+
+// WithFixedValues fixes the listed variables (by column index) to the
+// given values before solving, leaving the rest free to re-optimize.
+// Each value must lie within the variable's bounds in the model.
+func WithFixedValues(values map[int]float64) SolveOption {
+	return func(c *solveConfig) {
+		if c.fixedValues == nil {
+			c.fixedValues = make(map[int]float64, len(values))
+		}
+		for col, v := range values {
+			c.fixedValues[col] = v
+		}
+	}
+}
+
+// WithOptionsFingerprint records a digest of the HiGHS options in effect
+// in Solution.Info.OptionsFingerprint, to tell whether two results were
+// produced under the same configuration. It is off by default because
+// reading every option back costs several calls into HiGHS per option.
+func WithOptionsFingerprint() SolveOption {
+	return func(c *solveConfig) {
+		c.optionsFingerprint = true
+	}
+}
+
+// WithRootRelaxationBound solves the LP relaxation of the model before
+// the solve itself and records its objective in
+// Solution.Info.RootRelaxationObjective, giving a bound to compare the
+// integer objective against. The relaxation is solved with the same
+// options, so for MIPs this roughly adds the cost of one LP solve.
+func WithRootRelaxationBound() SolveOption {
+	return func(c *solveConfig) {
+		c.rootRelaxation = true
+	}
+}
+
+// WithSecondaryObjective breaks ties between multiple optimal solutions
+// deterministically. After the primary optimum is found, the model is
+// re-solved with the primary objective held at its optimum and costs
+// minimized instead. For example, costs of all ones select an optimal
+// solution with the smallest sum of variables, and costs that grow
+// steeply with the column index favor small values in later columns.
+//
+// The returned Objective is the primary objective, while the duals and
+// basis belong to the tie-breaking solve. Quadratic objectives are not
+// supported.
+func WithSecondaryObjective(costs []float64) SolveOption {
+	return func(c *solveConfig) {
+		c.secondaryObjective = costs
+	}
+}
+
+// WithInitialBasis starts the solve from the given basis, typically the
+// ColBasis and RowBasis of a previous solution after a small change to the
+// model such as new ColCosts. The basis must match the model's current
+// number of variables and constraints and have one basic status per
+// constraint; otherwise Solve returns an error.
+func WithInitialBasis(colBasis, rowBasis []BasisStatus) SolveOption {
+	return func(c *solveConfig) {
+		c.initialBasis = true
+		c.colBasis = colBasis
+		c.rowBasis = rowBasis
+	}
+}
+
+// WithWarmStart starts the solve from a previous solution of the same or
+// a slightly changed model. Its basis is used as with WithInitialBasis;
+// a solution without a basis, as of a MIP, is instead passed to HiGHS as
+// a starting solution (MIP start). Either must match the model's current
+// dimensions, or Solve returns an error.
+func WithWarmStart(prev *Solution) SolveOption {
+	return func(c *solveConfig) {
+		c.warmStart = prev
+	}
+}
+
+// WithZeroThreshold cleans the solution with Solution.Clean(tol), so
+// values and duals smaller than tol in magnitude are exactly zero. Values
+// are left unaltered unless this option is given.
+func WithZeroThreshold(tol float64) SolveOption {
+	return func(c *solveConfig) {
+		c.zeroThreshold = tol
+	}
+}
+
+// WithLogWriter sends the solver log to w, independently of WithOutput.
+// See Solver.SetLogWriter.
+func WithLogWriter(w io.Writer) SolveOption {
+	return func(c *solveConfig) {
+		c.logWriter = w
+	}
+}
+
+// traceLogDevLevel is the log_dev_level used by WithSolveTrace
+// (kHighsLogDevLevelDetailed).
+const traceLogDevLevel = 2
+
+// WithSolveTrace writes a detailed solver trace, including per-iteration
+// progress, to the file at path, which is created or truncated. It enables
+// output, sets log_file and raises log_dev_level, and sends the log only to
+// the file, overriding WithOutput.
+//
+// Detailed logging formats and writes a line for many internal steps, so
+// it noticeably slows down solves with many iterations or nodes; use it
+// for debugging only. Warnings logged by HiGHS go to the trace rather than
+// Solution.Warnings.
+func WithSolveTrace(path string) SolveOption {
+	return func(c *solveConfig) {
+		c.tracePath = path
+	}
+}
+
 // WithBoolOption sets a custom boolean option.
 func WithBoolOption(name string, value bool) SolveOption {
 	return func(c *solveConfig) {
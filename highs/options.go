@@ -0,0 +1,99 @@
+package highs
+
+// SolverOptions holds typed values for commonly used HiGHS options, for
+// bulk configuration with SetOptions. Nil fields are left unchanged.
+// Options not covered here can be set with the Set*Option methods.
+type SolverOptions struct {
+	// Output enables solver log output (output_flag).
+	Output *bool
+
+	// TimeLimit is the time limit in seconds (time_limit).
+	TimeLimit *float64
+
+	// Threads is the number of threads to use (threads).
+	Threads *int
+
+	// Presolve is "off", "choose", or "on" (presolve).
+	Presolve *string
+
+	// Solver selects the algorithm: "simplex", "choose", "ipm", or
+	// "pdlp" (solver).
+	Solver *string
+
+	// Parallel is "off", "choose", or "on" (parallel).
+	Parallel *string
+
+	// RandomSeed seeds the solver's random number generator (random_seed).
+	RandomSeed *int
+
+	// PrimalFeasibilityTolerance is primal_feasibility_tolerance.
+	PrimalFeasibilityTolerance *float64
+
+	// DualFeasibilityTolerance is dual_feasibility_tolerance.
+	DualFeasibilityTolerance *float64
+
+	// MIPRelGap is the relative MIP gap tolerance (mip_rel_gap).
+	MIPRelGap *float64
+
+	// MIPAbsGap is the absolute MIP gap tolerance (mip_abs_gap).
+	MIPAbsGap *float64
+
+	// MIPMaxNodes limits the number of branch-and-bound nodes
+	// (mip_max_nodes).
+	MIPMaxNodes *int
+}
+
+// SetOptions applies every non-nil field of opts, stopping at the first
+// option HiGHS rejects.
+func (s *Solver) SetOptions(opts SolverOptions) error {
+	if opts.Output != nil {
+		if err := s.SetBoolOption("output_flag", *opts.Output); err != nil {
+			return err
+		}
+	}
+	for _, o := range []struct {
+		name  string
+		value *int
+	}{
+		{"threads", opts.Threads},
+		{"random_seed", opts.RandomSeed},
+		{"mip_max_nodes", opts.MIPMaxNodes},
+	} {
+		if o.value != nil {
+			if err := s.SetIntOption(o.name, *o.value); err != nil {
+				return err
+			}
+		}
+	}
+	for _, o := range []struct {
+		name  string
+		value *float64
+	}{
+		{"time_limit", opts.TimeLimit},
+		{"primal_feasibility_tolerance", opts.PrimalFeasibilityTolerance},
+		{"dual_feasibility_tolerance", opts.DualFeasibilityTolerance},
+		{"mip_rel_gap", opts.MIPRelGap},
+		{"mip_abs_gap", opts.MIPAbsGap},
+	} {
+		if o.value != nil {
+			if err := s.SetFloatOption(o.name, *o.value); err != nil {
+				return err
+			}
+		}
+	}
+	for _, o := range []struct {
+		name  string
+		value *string
+	}{
+		{"presolve", opts.Presolve},
+		{"solver", opts.Solver},
+		{"parallel", opts.Parallel},
+	} {
+		if o.value != nil {
+			if err := s.SetStringOption(o.name, *o.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package highs
+
+import (
+	"fmt"
+	"math"
+)
+
+// AddPiecewiseLinear adds a variable y equal to the piecewise-linear
+// interpolation of the points (breakpoints[i], values[i]) at variable
+// xCol, and returns its column index. x is restricted to
+// [breakpoints[0], breakpoints[n-1]].
+//
+// HiGHS has no SOS2 constraints, so they are encoded with binaries: a
+// weight λ_i in [0, 1] per breakpoint with x = Σ λ_i·breakpoints[i],
+// y = Σ λ_i·values[i], and Σ λ_i = 1, plus a binary z_j per segment with
+// Σ z_j = 1 that allows only the two weights of the chosen segment to be
+// nonzero. This appends 2n variables (n weights, n-1 binaries, then y)
+// and n+4 constraints, and makes the model a MIP.
+//
+// Example:
+//
+//	y, _ := model.AddPiecewiseLinear(0, []float64{0, 1, 2}, []float64{0, 1, 4})
+//	// Variable y approximates x0² on [0, 2]
+func (m *Model) AddPiecewiseLinear(xCol int, breakpoints, values []float64) (yCol int, err error) {
+	n := len(breakpoints)
+	if n < 2 {
+		return -1, newErrorMsg("AddPiecewiseLinear", "at least two breakpoints are required")
+	}
+	if len(values) != n {
+		return -1, newErrorMsg("AddPiecewiseLinear", "breakpoints and values must have same length")
+	}
+	for i := 1; i < n; i++ {
+		if !(breakpoints[i] > breakpoints[i-1]) {
+			return -1, newErrorMsg("AddPiecewiseLinear", "breakpoints must be strictly increasing")
+		}
+	}
+	if numCol := m.NumVars(); xCol < 0 || xCol >= numCol {
+		return -1, newErrorMsg("AddPiecewiseLinear", fmt.Sprintf("column index %d out of range [0, %d)", xCol, numCol))
+	}
+
+	lambda := m.NumVars()
+	segment := lambda + n
+	yCol = segment + n - 1
+	for i := 0; i < n; i++ {
+		m.addVar(lambda+i, 0, 0, 1)
+	}
+	for j := 0; j < n-1; j++ {
+		m.addVar(segment+j, 0, 0, 1)
+		m.setVarType(segment+j, Integer)
+	}
+	m.addVar(yCol, 0, math.Inf(-1), math.Inf(1))
+
+	weights := make([]int, n)
+	ones := make([]float64, n)
+	for i := range weights {
+		weights[i] = lambda + i
+		ones[i] = 1
+	}
+	m.AddSparseRow(1, weights, ones, 1)
+
+	// x and y are the weighted sums of the breakpoints and values
+	m.AddSparseRow(0, append([]int{xCol}, weights...), append([]float64{-1}, breakpoints...), 0)
+	m.AddSparseRow(0, append([]int{yCol}, weights...), append([]float64{-1}, values...), 0)
+
+	segments := make([]int, n-1)
+	for j := range segments {
+		segments[j] = segment + j
+	}
+	m.AddSparseRow(1, segments, ones[:n-1], 1)
+
+	// λ_i may be nonzero only if an adjacent segment is chosen
+	for i := 0; i < n; i++ {
+		cols := []int{lambda + i}
+		coeffs := []float64{1}
+		for _, j := range []int{i - 1, i} {
+			if j >= 0 && j < n-1 {
+				cols = append(cols, segment+j)
+				coeffs = append(coeffs, -1)
+			}
+		}
+		m.AddSparseRow(math.Inf(-1), cols, coeffs, 0)
+	}
+	return yCol, nil
+}
+
+// setVarType sets the type of column col, padding VarTypes with
+// Continuous as needed.
+func (m *Model) setVarType(col int, t VariableType) {
+	for len(m.VarTypes) <= col {
+		m.VarTypes = append(m.VarTypes, Continuous)
+	}
+	m.VarTypes[col] = t
+}
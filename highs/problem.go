@@ -0,0 +1,125 @@
+package highs
+
+import (
+	"math"
+	"sort"
+)
+
+// Problem is a read-only view of an LP, MIP, or QP problem. It lets
+// analysis and transformation code be written independently of how the
+// problem is stored. *Model is the concrete default implementation.
+type Problem interface {
+	// NumVars returns the number of variables.
+	NumVars() int
+
+	// NumConstraints returns the number of constraints.
+	NumConstraints() int
+
+	// Variables returns every variable in column order.
+	Variables() []Variable
+
+	// Constraints returns every constraint in row order.
+	Constraints() []Constraint
+
+	// ObjectiveTerms returns the objective function.
+	ObjectiveTerms() Objective
+}
+
+var _ Problem = (*Model)(nil)
+
+// Variable describes a single column of a Problem.
+type Variable struct {
+	Lower float64
+	Upper float64
+	Cost  float64
+	Type  VariableType
+}
+
+// Constraint describes a single row of a Problem: Lower ≤ Σ Coeffs·x ≤ Upper.
+type Constraint struct {
+	Lower float64
+	Upper float64
+	// Cols and Coeffs hold the row's nonzeros, sorted by column.
+	Cols   []int
+	Coeffs []float64
+}
+
+// Objective describes the objective function of a Problem:
+// Linear · x + Offset + 0.5 * x' * Quadratic * x.
+type Objective struct {
+	Maximize bool
+	Offset   float64
+	// Linear has one coefficient per variable.
+	Linear []float64
+	// Quadratic holds the upper-triangular Hessian entries.
+	Quadratic []Nonzero
+}
+
+// Variables returns every variable in column order, with missing bounds
+// and costs filled in with their defaults.
+func (m *Model) Variables() []Variable {
+	vars := make([]Variable, m.NumVars())
+	for i := range vars {
+		v := Variable{Lower: math.Inf(-1), Upper: math.Inf(1), Type: Continuous}
+		if i < len(m.ColLower) {
+			v.Lower = m.ColLower[i]
+		}
+		if i < len(m.ColUpper) {
+			v.Upper = m.ColUpper[i]
+		}
+		if i < len(m.ColCosts) {
+			v.Cost = m.ColCosts[i]
+		}
+		if i < len(m.VarTypes) {
+			v.Type = m.VarTypes[i]
+		}
+		vars[i] = v
+	}
+	return vars
+}
+
+// Constraints returns every constraint in row order. Duplicate matrix
+// entries are merged (keeping the last value) and explicit zeros dropped.
+func (m *Model) Constraints() []Constraint {
+	cons := make([]Constraint, m.NumConstraints())
+	for i := range cons {
+		cons[i].Lower, cons[i].Upper = math.Inf(-1), math.Inf(1)
+		if i < len(m.RowLower) {
+			cons[i].Lower = m.RowLower[i]
+		}
+		if i < len(m.RowUpper) {
+			cons[i].Upper = m.RowUpper[i]
+		}
+	}
+
+	entries := canonicalEntries(m.ConstMatrix)
+	keys := make([][2]int, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		c := &cons[k[0]]
+		c.Cols = append(c.Cols, k[1])
+		c.Coeffs = append(c.Coeffs, entries[k])
+	}
+	return cons
+}
+
+// ObjectiveTerms returns the objective function. Linear is padded to
+// NumVars entries; Quadratic shares the model's Hessian slice.
+func (m *Model) ObjectiveTerms() Objective {
+	linear := make([]float64, m.NumVars())
+	copy(linear, m.ColCosts)
+	return Objective{
+		Maximize:  m.Maximize,
+		Offset:    m.Offset,
+		Linear:    linear,
+		Quadratic: m.Hessian,
+	}
+}
@@ -0,0 +1,182 @@
+package highs
+
+import "time"
+
+// progressInterval is the minimum time between throttled progress reports.
+const progressInterval = 100 * time.Millisecond
+
+// Progress is a snapshot of a running solve, reported by
+// WithProgressReporter and suitable for driving a progress bar.
+type Progress struct {
+	// Elapsed is the time since the solve started.
+	Elapsed time.Duration
+
+	// Nodes is the number of branch-and-bound nodes explored so far.
+	Nodes int64
+
+	// Gap is the relative MIP gap; +Inf while there is no incumbent.
+	Gap float64
+
+	// Incumbent is the objective of the best solution found so far
+	// (±Inf while there is none).
+	Incumbent float64
+
+	// Bound is the best proven bound on the objective.
+	Bound float64
+
+	// Done is set on the final report, sent once the solve returns.
+	Done bool
+}
+
+// CallbackData is the solver state passed to an interrupt callback set
+// with Solver.SetInterruptCallback.
+type CallbackData struct {
+	// Elapsed is the time since the solve started.
+	Elapsed time.Duration
+
+	// SimplexIterations and IPMIterations count the iterations so far.
+	SimplexIterations int
+	IPMIterations     int
+
+	// Objective is the current objective value of the simplex or IPM
+	// solver.
+	Objective float64
+
+	// MIP is set when the callback comes from the MIP solver; the
+	// remaining fields are only meaningful then.
+	MIP bool
+
+	// Nodes is the number of branch-and-bound nodes explored so far.
+	Nodes int64
+
+	// Incumbent is the objective of the best solution found so far
+	// (±Inf while there is none).
+	Incumbent float64
+
+	// Bound is the best proven bound on the objective.
+	Bound float64
+
+	// Gap is the relative MIP gap; +Inf while there is no incumbent.
+	Gap float64
+}
+
+// WithProgressReporter calls report with the progress of a MIP solve.
+//
+// Reports are throttled: one is sent on the first MIP callback, then
+// whenever the incumbent changes or at most every 100ms otherwise, and a
+// final report with Done set once the solve returns (also for LPs).
+// report runs on the solving goroutine and should return quickly.
+func WithProgressReporter(report func(Progress)) SolveOption {
+	return func(c *solveConfig) {
+		c.progress = report
+	}
+}
+
+// progressReporter throttles MIP callback events into Progress reports.
+type progressReporter struct {
+	report   func(Progress)
+	last     Progress
+	lastTime time.Duration
+	reported bool
+}
+
+// observe reports p unless it is throttled: it is always reported if it
+// is the first report or the incumbent changed, and otherwise only if
+// progressInterval has passed since the last report.
+func (r *progressReporter) observe(p Progress) {
+	improved := p.Incumbent != r.last.Incumbent
+	if r.reported && !improved && p.Elapsed-r.lastTime < progressInterval {
+		return
+	}
+	r.emit(p)
+}
+
+func (r *progressReporter) emit(p Progress) {
+	r.last = p
+	r.lastTime = p.Elapsed
+	r.reported = true
+	r.report(p)
+}
+
+// MIPProgress is a MIP progress event delivered by
+// Model.SolveWithProgress.
+type MIPProgress struct {
+	// Elapsed is the time since the solve started.
+	Elapsed time.Duration
+
+	// Improving is set when the event reports a new incumbent rather
+	// than a line of the MIP log.
+	Improving bool
+
+	// Objective is the objective of the best solution found so far
+	// (±Inf while there is none).
+	Objective float64
+
+	// Bound is the best proven bound on the objective.
+	Bound float64
+
+	// Gap is the relative MIP gap; +Inf while there is no incumbent.
+	Gap float64
+
+	// Nodes is the number of branch-and-bound nodes explored so far.
+	Nodes int64
+}
+
+// mipProgressBuffer is the number of MIP progress events a ProgressSolve
+// holds for a reader that has fallen behind.
+const mipProgressBuffer = 256
+
+// ProgressSolve is a solve running on its own goroutine, started by
+// Model.SolveWithProgress.
+type ProgressSolve struct {
+	events chan MIPProgress
+	done   chan struct{}
+	sol    *Solution
+	err    error
+}
+
+// SolveWithProgress starts solving the model like Solve on a new
+// goroutine and returns at once. Events streams one MIP progress event
+// for each improving solution and each line of the MIP log, in order,
+// while the solve runs; Wait returns its result. The model must not be
+// changed until Wait returns.
+//
+// Events are sent without blocking the solver: when the channel buffer
+// is full because the reader has fallen behind, further events are
+// dropped until there is room again. Nothing is left running if the
+// caller never reads them. For every report, use WithProgressReporter,
+// which runs on the solving goroutine.
+func (m *Model) SolveWithProgress(opts ...SolveOption) *ProgressSolve {
+	p := &ProgressSolve{
+		events: make(chan MIPProgress, mipProgressBuffer),
+		done:   make(chan struct{}),
+	}
+	send := func(c *solveConfig) {
+		c.mipProgress = func(e MIPProgress) {
+			select {
+			case p.events <- e:
+			default:
+			}
+		}
+	}
+	opts = append(opts[:len(opts):len(opts)], send)
+	go func() {
+		defer close(p.done)
+		defer close(p.events)
+		p.sol, p.err = m.Solve(opts...)
+	}()
+	return p
+}
+
+// Events returns the channel of MIP progress events. It is closed when
+// the solve finishes.
+func (p *ProgressSolve) Events() <-chan MIPProgress {
+	return p.events
+}
+
+// Wait blocks until the solve finishes and returns its result, as Solve
+// would.
+func (p *ProgressSolve) Wait() (*Solution, error) {
+	<-p.done
+	return p.sol, p.err
+}
@@ -1,10 +1,22 @@
 package highs
 
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
 // Solution contains the results from solving an optimization model.
 type Solution struct {
 	// Status indicates the outcome of the solve.
 	Status ModelStatus
 
+	// StatusClassifiedFrom holds the status HiGHS reported when Model.Solve
+	// replaced it with a more specific one, as it does for a MIP reported
+	// as UnboundedOrInfeasible, and ModelStatusNotSet otherwise.
+	StatusClassifiedFrom ModelStatus
+
 	// ColValues contains the primal solution values for each column (variable).
 	ColValues []float64
 
@@ -20,15 +32,71 @@ type Solution struct {
 	RowDuals []float64
 
 	// ColBasis contains the basis status for each column.
-	// Only populated when a basis is available.
+	// Only populated when HiGHS holds a valid basis, which includes
+	// solves stopped by a time or iteration limit.
 	ColBasis []BasisStatus
 
 	// RowBasis contains the basis status for each row.
-	// Only populated when a basis is available.
+	// Only populated when HiGHS holds a valid basis, which includes
+	// solves stopped by a time or iteration limit.
 	RowBasis []BasisStatus
 
 	// Objective is the value of the objective function at the solution.
 	Objective float64
+
+	// Populated reports whether HiGHS actually returned primal values.
+	// It distinguishes "no solution" from a genuine all-zero solution,
+	// for example when an empty model is solved without calling HiGHS.
+	Populated bool
+
+	// Info contains additional details about how the solution was obtained.
+	Info SolveInfo
+
+	// Warnings contains non-fatal diagnostics raised while solving,
+	// including the warnings HiGHS logs during the run, captured even
+	// when output is disabled. HiGHS warnings are missing when a log_file
+	// is set, as with WithSolveTrace, and from Solver.Run unless
+	// Solver.SetWarningCapture(true) was called.
+	Warnings []string
+
+	// Pool holds the distinct feasible solutions found during a MIP
+	// solve, in the order found, when solving with WithCollectSolutions.
+	Pool []PoolEntry
+
+	// RowViolations holds, for an infeasible model solved with
+	// WithRowViolations, how far each constraint's activity lies outside
+	// its bounds at a point minimizing the total violation.
+	RowViolations []float64
+}
+
+// PoolEntry is one feasible solution collected by WithCollectSolutions.
+type PoolEntry struct {
+	// Objective is the objective value of the incumbent.
+	Objective float64
+
+	// ColValues holds the incumbent's value for each column.
+	ColValues []float64
+}
+
+// SolveInfo contains details about the solve that produced a solution.
+type SolveInfo struct {
+	// Version is the HiGHS library version used for the solve.
+	Version string
+
+	// RootRelaxationObjective is the objective of the LP relaxation,
+	// recorded when solving with WithRootRelaxationBound. It is ±Inf if
+	// the relaxation is unbounded and NaN if it has no optimal solution.
+	RootRelaxationObjective float64
+
+	// OptionsFingerprint is a short digest of the HiGHS options in effect,
+	// as from Solver.OptionsFingerprint; set by Model.Solve when solving
+	// with WithOptionsFingerprint. Identical configurations have identical
+	// fingerprints.
+	OptionsFingerprint string
+
+	// SimplexIterations is the number of simplex iterations of the solve,
+	// zero if it used no simplex iterations.
+	SimplexIterations int
 }
 
 // IsOptimal returns true if the solution is optimal.
@@ -66,3 +134,581 @@ func (s *Solution) Value(index int) float64 {
 	}
 	return s.ColValues[index]
 }
+
+// Clean sets every entry of ColValues, RowValues, ColDuals, and RowDuals
+// whose magnitude is below tol to exactly zero, removing numerical noise
+// such as 1e-13 from the output. The objective is not changed.
+func (s *Solution) Clean(tol float64) {
+	for _, values := range [][]float64{s.ColValues, s.RowValues, s.ColDuals, s.RowDuals} {
+		for i, v := range values {
+			if math.Abs(v) < tol {
+				values[i] = 0
+			}
+		}
+	}
+}
+
+// RankByReducedCost returns the variable indices sorted by increasing
+// magnitude of their reduced cost (ColDuals), ties broken by index.
+// Basic variables have zero reduced cost and come first; among nonbasic
+// variables, the earliest are closest to entering the basis. Returns nil
+// if ColDuals is empty. The ranking is only meaningful for an LP: for a
+// MIP, Run fills ColDuals with zeros, so every variable ties and the
+// ranking is just the index order.
+func (s *Solution) RankByReducedCost() []int {
+	if len(s.ColDuals) == 0 {
+		return nil
+	}
+	ranking := make([]int, len(s.ColDuals))
+	for i := range ranking {
+		ranking[i] = i
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return math.Abs(s.ColDuals[ranking[i]]) < math.Abs(s.ColDuals[ranking[j]])
+	})
+	return ranking
+}
+
+// ObjectiveGradient returns the gradient of the model's objective at the
+// solution, c + Q·x, where c is ColCosts and Q the symmetric Hessian whose
+// upper triangle is stored in model.Hessian. At an optimal point of a QP
+// it equals A'·RowDuals + ColDuals (the KKT stationarity condition).
+// Returns nil if there are no primal values or the Hessian is invalid.
+func (s *Solution) ObjectiveGradient(model *Model) []float64 {
+	numCol := len(s.ColValues)
+	if numCol == 0 {
+		return nil
+	}
+	grad := make([]float64, numCol)
+	copy(grad, model.ColCosts)
+
+	// Merge duplicates as load does, then apply each entry symmetrically
+	start, index, value, err := nonzerosToCSR(model.Hessian, numCol, true)
+	if err != nil {
+		return nil
+	}
+	for row := range start {
+		end := len(index)
+		if row+1 < len(start) {
+			end = start[row+1]
+		}
+		for k := start[row]; k < end; k++ {
+			col := index[k]
+			if col >= numCol {
+				return nil
+			}
+			grad[row] += value[k] * s.ColValues[col]
+			if col != row {
+				grad[col] += value[k] * s.ColValues[row]
+			}
+		}
+	}
+	return grad
+}
+
+// unboundedDirectionTol is the minimum objective improvement per unit
+// step required of an unbounded direction.
+const unboundedDirectionTol = 1e-9
+
+// UnboundedDirection returns a direction d along which the objective of
+// the model loaded in solver improves without bound: x + t·d stays
+// feasible for all t >= 0 and the objective decreases (increases when
+// maximizing) as t grows. It is the primal ray computed by HiGHS, checked
+// against the linear objective before it is returned.
+//
+// The solution must have status Unbounded or UnboundedOrInfeasible, and
+// the solver must be the one that produced it. An error is returned if
+// HiGHS finds no ray, as when an UnboundedOrInfeasible model turns out to
+// be infeasible.
+func (s *Solution) UnboundedDirection(solver *Solver) ([]float64, error) {
+	if s.Status != ModelStatusUnbounded && s.Status != ModelStatusUnboundedOrInfeasible {
+		return nil, newErrorMsg("UnboundedDirection", fmt.Sprintf("model status is %s, not unbounded", s.Status))
+	}
+	ray, ok, err := solver.PrimalRay()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, newErrorMsg("UnboundedDirection", "no primal ray available")
+	}
+
+	if len(ray) == 0 {
+		return nil, newErrorMsg("UnboundedDirection", "model has no columns")
+	}
+	cols, err := solver.GetColsByRange(0, len(ray)-1)
+	if err != nil {
+		return nil, err
+	}
+	maximize, err := solver.ObjectiveSense()
+	if err != nil {
+		return nil, err
+	}
+	slope := 0.0
+	for col, d := range ray {
+		slope += cols.Cost[col] * d
+	}
+	if maximize {
+		slope = -slope
+	}
+	if slope > -unboundedDirectionTol {
+		return nil, newErrorMsg("UnboundedDirection", fmt.Sprintf("primal ray does not improve the objective (slope %g)", slope))
+	}
+	return ray, nil
+}
+
+// semiContinuousTol is the tolerance below which a semi-continuous
+// variable is considered switched off.
+const semiContinuousTol = 1e-9
+
+// SemiContinuousStatus classifies each semi-continuous and semi-integer
+// variable of the model as "off" (the value is zero) or "on" (the value
+// is at or above the threshold given by its column lower bound).
+// Entries for other variable types are empty strings.
+func (s *Solution) SemiContinuousStatus(model *Model) []string {
+	status := make([]string, len(s.ColValues))
+	for i := range status {
+		if i >= len(model.VarTypes) {
+			break
+		}
+		if vt := model.VarTypes[i]; vt != SemiContinuous && vt != SemiInteger {
+			continue
+		}
+		if math.Abs(s.ColValues[i]) <= semiContinuousTol {
+			status[i] = "off"
+		} else {
+			status[i] = "on"
+		}
+	}
+	return status
+}
+
+// ColValueMap returns the value of each variable keyed by its name in
+// model.ColNames, with unnamed variables given the default names
+// described there. If several variables share a name, the later ones are
+// keyed by the name with the smallest free suffix "_2", "_3", ...
+// instead, so no value is lost.
+func (s *Solution) ColValueMap(model *Model) map[string]float64 {
+	names := defaultNames(model.ColNames, len(s.ColValues), "C")
+	values := make(map[string]float64, len(s.ColValues))
+	for col, v := range s.ColValues {
+		name := names[col]
+		key := name
+		for k := 2; ; k++ {
+			if _, taken := values[key]; !taken {
+				break
+			}
+			key = fmt.Sprintf("%s_%d", name, k)
+		}
+		values[key] = v
+	}
+	return values
+}
+
+// ShadowPrice returns the shadow price of the given row: the marginal
+// change in the optimal objective per unit increase of the row's active
+// bound (its right-hand side), whether the model is minimized or
+// maximized. A positive value means raising the bound increases the
+// objective. Rows that are not binding, free rows, and rows outside the
+// solution have a shadow price of 0.
+//
+// HiGHS already reports RowDuals in this convention for both objective
+// senses, so unlike a textbook dual it needs no sign flip for
+// maximization or for >= rows; ShadowPrice exists to pin it down.
+func (s *Solution) ShadowPrice(model *Model, row int) float64 {
+	if row < 0 || row >= len(s.RowDuals) {
+		return 0
+	}
+	if row < len(model.RowLower) && row < len(model.RowUpper) &&
+		!isFiniteBound(model.RowLower[row]) && !isFiniteBound(model.RowUpper[row]) {
+		return 0
+	}
+	if d := s.RowDuals[row]; d != 0 {
+		return d
+	}
+	return 0 // normalize -0
+}
+
+// RHSRanges returns, for each row of model, how far its right-hand side
+// can move while the solution's basis stays optimal, answering how much
+// slack each resource has. Since a Solution does not keep its solver,
+// model is reloaded with the solution's basis, which re-solves without
+// iterations, and ranged with Solver.Ranging. The solution must be an
+// optimal LP solution with a basis.
+func (s *Solution) RHSRanges(model *Model) ([]RHSRange, error) {
+	if len(s.ColBasis) == 0 && len(s.RowBasis) == 0 {
+		return nil, newErrorMsg("RHSRanges", "solution has no basis")
+	}
+	solver, err := NewSolver()
+	if err != nil {
+		return nil, err
+	}
+	defer solver.Close()
+	if err := solver.SetBoolOption("output_flag", false); err != nil {
+		return nil, err
+	}
+	if err := model.load(solver); err != nil {
+		return nil, err
+	}
+	if err := solver.SetBasis(s.ColBasis, s.RowBasis); err != nil {
+		return nil, err
+	}
+	sol, err := solver.Run()
+	if err != nil {
+		return nil, err
+	}
+	ranging, err := solver.Ranging()
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]RHSRange, len(sol.RowValues))
+	for row, activity := range sol.RowValues {
+		lower, upper := math.Inf(-1), math.Inf(1)
+		if row < len(model.RowLower) && isFiniteBound(model.RowLower[row]) {
+			lower = model.RowLower[row]
+		}
+		if row < len(model.RowUpper) && isFiniteBound(model.RowUpper[row]) {
+			upper = model.RowUpper[row]
+		}
+		r := &ranges[row]
+		switch {
+		case sol.RowBasis[row] == BasisStatusLower:
+			r.Bound = lower
+		case sol.RowBasis[row] == BasisStatusUpper:
+			r.Bound = upper
+		case !math.IsInf(upper, 1) && (math.IsInf(lower, -1) || upper-activity <= activity-lower):
+			*r = RHSRange{Bound: upper, Lower: activity, Upper: math.Inf(1)}
+			continue
+		case !math.IsInf(lower, -1):
+			*r = RHSRange{Bound: lower, Lower: math.Inf(-1), Upper: activity}
+			continue
+		default:
+			*r = RHSRange{Bound: math.Inf(1), Lower: math.Inf(-1), Upper: math.Inf(1)}
+			continue
+		}
+		r.Lower = ranging.RowBoundDown.Value[row]
+		r.Upper = ranging.RowBoundUp.Value[row]
+	}
+	return ranges, nil
+}
+
+// RowActivityByName returns the activity of the constraint with the given
+// name in model.RowNames, where unnamed rows have the default names
+// described there, such as "R<i>" for row i. If several rows share the
+// name, the first is used. It returns an error wrapping ErrUnknownName if
+// no row has the name.
+func (s *Solution) RowActivityByName(model *Model, name string) (float64, error) {
+	for row, rowName := range defaultNames(model.RowNames, len(s.RowValues), "R") {
+		if rowName == name {
+			return s.RowValues[row], nil
+		}
+	}
+	return 0, fmt.Errorf("%w: row %q", ErrUnknownName, name)
+}
+
+// solutionEncodingVersion is the first byte of the MarshalBinary layout.
+const solutionEncodingVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler using a compact
+// little-endian layout: a version byte, the status, objective, and
+// Populated flag, followed by each slice as a uint32 length and its
+// elements, then the strings in the same length-prefixed form, and
+// the pool as a count of entries, each an objective and its column
+// values, then RowViolations, and finally StatusClassifiedFrom. Empty and
+// nil slices encode identically and decode as nil.
+func (s *Solution) MarshalBinary() ([]byte, error) {
+	size := 1 + 8 + 8 + 1 + 6*4 +
+		8*(len(s.ColValues)+len(s.ColDuals)+len(s.RowValues)+len(s.RowDuals)) +
+		len(s.ColBasis) + len(s.RowBasis) + 4 + len(s.Info.Version) + 8 +
+		4 + len(s.Info.OptionsFingerprint) + 8 + 4
+	for _, w := range s.Warnings {
+		size += 4 + len(w)
+	}
+	size += 4
+	for _, e := range s.Pool {
+		size += 8 + 4 + 8*len(e.ColValues)
+	}
+	size += 4 + 8*len(s.RowViolations) + 8
+
+	b := make([]byte, 0, size)
+	b = append(b, solutionEncodingVersion)
+	b = binary.LittleEndian.AppendUint64(b, uint64(int64(s.Status)))
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(s.Objective))
+	if s.Populated {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	for _, v := range [][]float64{s.ColValues, s.ColDuals, s.RowValues, s.RowDuals} {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(v)))
+		for _, f := range v {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(f))
+		}
+	}
+	for _, v := range [][]BasisStatus{s.ColBasis, s.RowBasis} {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(v)))
+		for _, st := range v {
+			b = append(b, byte(st))
+		}
+	}
+	b = appendString(b, s.Info.Version)
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(s.Info.RootRelaxationObjective))
+	b = appendString(b, s.Info.OptionsFingerprint)
+	b = binary.LittleEndian.AppendUint64(b, uint64(s.Info.SimplexIterations))
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s.Warnings)))
+	for _, w := range s.Warnings {
+		b = appendString(b, w)
+	}
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s.Pool)))
+	for _, e := range s.Pool {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(e.Objective))
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(e.ColValues)))
+		for _, f := range e.ColValues {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(f))
+		}
+	}
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s.RowViolations)))
+	for _, f := range s.RowViolations {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(f))
+	}
+	b = binary.LittleEndian.AppendUint64(b, uint64(int64(s.StatusClassifiedFrom)))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for the layout
+// written by MarshalBinary.
+func (s *Solution) UnmarshalBinary(data []byte) error {
+	d := decoder{data: data}
+	version := d.byte()
+	if version != solutionEncodingVersion {
+		return newErrorMsg("UnmarshalBinary", "unsupported solution encoding version")
+	}
+
+	var sol Solution
+	sol.Status = ModelStatus(int64(d.uint64()))
+	sol.Objective = math.Float64frombits(d.uint64())
+	sol.Populated = d.byte() != 0
+	for _, v := range []*[]float64{&sol.ColValues, &sol.ColDuals, &sol.RowValues, &sol.RowDuals} {
+		*v = d.float64s()
+	}
+	for _, v := range []*[]BasisStatus{&sol.ColBasis, &sol.RowBasis} {
+		n := d.length(1)
+		if n > 0 {
+			*v = make([]BasisStatus, n)
+			for i := range *v {
+				(*v)[i] = BasisStatus(d.byte())
+			}
+		}
+	}
+	sol.Info.Version = d.string()
+	sol.Info.RootRelaxationObjective = math.Float64frombits(d.uint64())
+	sol.Info.OptionsFingerprint = d.string()
+	sol.Info.SimplexIterations = int(d.uint64())
+	if n := d.length(4); n > 0 {
+		sol.Warnings = make([]string, n)
+		for i := range sol.Warnings {
+			sol.Warnings[i] = d.string()
+		}
+	}
+	if n := d.length(12); n > 0 {
+		sol.Pool = make([]PoolEntry, n)
+		for i := range sol.Pool {
+			sol.Pool[i].Objective = math.Float64frombits(d.uint64())
+			sol.Pool[i].ColValues = d.float64s()
+		}
+	}
+	sol.RowViolations = d.float64s()
+	sol.StatusClassifiedFrom = ModelStatus(int64(d.uint64()))
+
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.data) != 0 {
+		return newErrorMsg("UnmarshalBinary", "trailing data after solution")
+	}
+	*s = sol
+	return nil
+}
+
+func appendString(b []byte, str string) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(str)))
+	return append(b, str...)
+}
+
+// decoder reads the MarshalBinary layout, recording the first error so
+// callers can check once at the end.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || len(d.data) < n {
+		d.err = newErrorMsg("UnmarshalBinary", "truncated solution data")
+		d.data = nil
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *decoder) byte() byte {
+	if b := d.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *decoder) uint64() uint64 {
+	if b := d.next(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// length reads a uint32 element count, rejecting counts that cannot fit
+// in the remaining data given the minimum element size.
+func (d *decoder) length(elemSize int) int {
+	b := d.next(4)
+	if b == nil {
+		return 0
+	}
+	n := int(binary.LittleEndian.Uint32(b))
+	if n*elemSize > len(d.data) {
+		d.err = newErrorMsg("UnmarshalBinary", "truncated solution data")
+		d.data = nil
+		return 0
+	}
+	return n
+}
+
+func (d *decoder) float64s() []float64 {
+	n := d.length(8)
+	if n == 0 {
+		return nil
+	}
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = math.Float64frombits(d.uint64())
+	}
+	return v
+}
+
+func (d *decoder) string() string {
+	return string(d.next(d.length(1)))
+}
+
+// bindingTol is the tolerance within which a row activity is considered
+// to be at one of its bounds.
+const bindingTol = 1e-7
+
+// GroupStats summarizes the constraints in one row group.
+type GroupStats struct {
+	// Rows is the number of constraints in the group.
+	Rows int
+	// Binding is the number of constraints at one of their bounds.
+	Binding int
+	// TotalSlack is the sum over the group of each row's distance to its
+	// nearest finite bound.
+	TotalSlack float64
+}
+
+// GroupReport summarizes the binding counts and slack of the model's
+// constraints per group, as assigned by Model.RowGroups. Rows without a
+// group are omitted.
+func (s *Solution) GroupReport(model *Model) map[string]GroupStats {
+	report := make(map[string]GroupStats)
+	for row, group := range model.RowGroups {
+		if group == "" || row >= len(s.RowValues) {
+			continue
+		}
+		stats := report[group]
+		stats.Rows++
+		slack := rowSlack(model, row, s.RowValues[row])
+		if slack <= bindingTol {
+			stats.Binding++
+		}
+		if !math.IsInf(slack, 1) {
+			stats.TotalSlack += slack
+		}
+		report[group] = stats
+	}
+	return report
+}
+
+// rowSlack returns the distance from activity to the nearest finite bound
+// of the row, or +Inf for a free row.
+func rowSlack(model *Model, row int, activity float64) float64 {
+	slack := math.Inf(1)
+	if row < len(model.RowLower) && isFiniteBound(model.RowLower[row]) {
+		slack = math.Min(slack, math.Max(0, activity-model.RowLower[row]))
+	}
+	if row < len(model.RowUpper) && isFiniteBound(model.RowUpper[row]) {
+		slack = math.Min(slack, math.Max(0, model.RowUpper[row]-activity))
+	}
+	return slack
+}
+
+// ActiveConstraints returns the indices of the constraints whose activity
+// is within tol of a finite bound, in increasing order.
+func (s *Solution) ActiveConstraints(model *Model, tol float64) []int {
+	var rows []int
+	for row, activity := range s.RowValues {
+		if rowSlack(model, row, activity) <= tol {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// ActiveSystem is the system of constraints active at a solution, which
+// defines the optimal face: the solution is unique if its matrix has full
+// column rank.
+type ActiveSystem struct {
+	// Rows are the binding constraints, as from ActiveConstraints.
+	Rows []int
+
+	// Cols are the variables within tolerance of a finite bound.
+	Cols []int
+
+	// Matrix has one row per entry of Rows, holding that constraint's
+	// coefficients, followed by one unit row per entry of Cols for its
+	// active bound. Columns are the model's variables.
+	Matrix []Nonzero
+}
+
+// ActiveSystem returns the constraints and variable bounds active at the
+// solution within tol, with the matrix they form, for degeneracy and
+// uniqueness analysis.
+func (s *Solution) ActiveSystem(model *Model, tol float64) *ActiveSystem {
+	sys := &ActiveSystem{Rows: s.ActiveConstraints(model, tol)}
+
+	position := make(map[int]int, len(sys.Rows))
+	for i, row := range sys.Rows {
+		position[row] = i
+	}
+	for _, nz := range model.ConstMatrix {
+		if i, ok := position[nz.Row]; ok && nz.Val != 0 {
+			sys.Matrix = append(sys.Matrix, Nonzero{Row: i, Col: nz.Col, Val: nz.Val})
+		}
+	}
+	sort.SliceStable(sys.Matrix, func(i, j int) bool {
+		return sys.Matrix[i].Row < sys.Matrix[j].Row
+	})
+
+	for col, v := range s.ColValues {
+		atLower := col < len(model.ColLower) && isFiniteBound(model.ColLower[col]) &&
+			math.Abs(v-model.ColLower[col]) <= tol
+		atUpper := col < len(model.ColUpper) && isFiniteBound(model.ColUpper[col]) &&
+			math.Abs(model.ColUpper[col]-v) <= tol
+		if atLower || atUpper {
+			sys.Matrix = append(sys.Matrix, Nonzero{Row: len(sys.Rows) + len(sys.Cols), Col: col, Val: 1})
+			sys.Cols = append(sys.Cols, col)
+		}
+	}
+	return sys
+}
@@ -0,0 +1,413 @@
+package highs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// VariableType specifies whether a variable is continuous, integer, etc.
+type VariableType int
+
+const (
+	// Continuous indicates a continuous variable (default).
+	Continuous VariableType = iota
+	// Integer indicates an integer variable.
+	Integer
+	// SemiContinuous indicates a semi-continuous variable.
+	SemiContinuous
+	// SemiInteger indicates a semi-integer variable.
+	SemiInteger
+	// ImplicitInteger indicates an implicit integer variable.
+	ImplicitInteger
+)
+
+// String returns a human-readable representation of the variable type.
+func (v VariableType) String() string {
+	switch v {
+	case Continuous:
+		return "Continuous"
+	case Integer:
+		return "Integer"
+	case SemiContinuous:
+		return "SemiContinuous"
+	case SemiInteger:
+		return "SemiInteger"
+	case ImplicitInteger:
+		return "ImplicitInteger"
+	default:
+		return "Unknown"
+	}
+}
+
+// Status represents the result status of a HiGHS operation.
+type Status int
+
+const (
+	// StatusError indicates the operation failed with an error.
+	StatusError Status = -1
+	// StatusOK indicates the operation succeeded.
+	StatusOK Status = 0
+	// StatusWarning indicates the operation succeeded with warnings.
+	StatusWarning Status = 1
+)
+
+// String returns a human-readable representation of the status.
+func (s Status) String() string {
+	switch s {
+	case StatusError:
+		return "Error"
+	case StatusOK:
+		return "OK"
+	case StatusWarning:
+		return "Warning"
+	default:
+		return "Unknown"
+	}
+}
+
+// ModelStatus represents the status of a solved model.
+type ModelStatus int
+
+const (
+	// ModelStatusNotSet indicates the model status has not been set.
+	ModelStatusNotSet ModelStatus = iota
+	// ModelStatusLoadError indicates an error loading the model.
+	ModelStatusLoadError
+	// ModelStatusModelError indicates an error in the model.
+	ModelStatusModelError
+	// ModelStatusPresolveError indicates an error during presolve.
+	ModelStatusPresolveError
+	// ModelStatusSolveError indicates an error during solve.
+	ModelStatusSolveError
+	// ModelStatusPostsolveError indicates an error during postsolve.
+	ModelStatusPostsolveError
+	// ModelStatusModelEmpty indicates the model is empty.
+	ModelStatusModelEmpty
+	// ModelStatusOptimal indicates an optimal solution was found.
+	ModelStatusOptimal
+	// ModelStatusInfeasible indicates the model is infeasible.
+	ModelStatusInfeasible
+	// ModelStatusUnboundedOrInfeasible indicates the model is unbounded or infeasible.
+	ModelStatusUnboundedOrInfeasible
+	// ModelStatusUnbounded indicates the model is unbounded.
+	ModelStatusUnbounded
+	// ModelStatusObjectiveBound indicates the objective bound was reached.
+	ModelStatusObjectiveBound
+	// ModelStatusObjectiveTarget indicates the objective target was reached.
+	ModelStatusObjectiveTarget
+	// ModelStatusTimeLimit indicates the time limit was reached.
+	ModelStatusTimeLimit
+	// ModelStatusIterationLimit indicates the iteration limit was reached.
+	ModelStatusIterationLimit
+	// ModelStatusUnknown indicates an unknown status.
+	ModelStatusUnknown
+	// ModelStatusSolutionLimit indicates the MIP solution limit was reached.
+	ModelStatusSolutionLimit
+	// ModelStatusInterrupt indicates the solve was interrupted by a callback.
+	ModelStatusInterrupt
+)
+
+// String returns a human-readable representation of the model status.
+func (s ModelStatus) String() string {
+	names := []string{
+		"NotSet", "LoadError", "ModelError", "PresolveError",
+		"SolveError", "PostsolveError", "ModelEmpty", "Optimal",
+		"Infeasible", "UnboundedOrInfeasible", "Unbounded",
+		"ObjectiveBound", "ObjectiveTarget", "TimeLimit",
+		"IterationLimit", "Unknown", "SolutionLimit", "Interrupt",
+	}
+	if int(s) >= 0 && int(s) < len(names) {
+		return names[s]
+	}
+	return "Unknown"
+}
+
+// IsOptimal returns true if the model was solved to optimality.
+func (s ModelStatus) IsOptimal() bool {
+	return s == ModelStatusOptimal
+}
+
+// HasSolution returns true if the model has a valid solution.
+func (s ModelStatus) HasSolution() bool {
+	return s == ModelStatusOptimal ||
+		s == ModelStatusObjectiveBound ||
+		s == ModelStatusObjectiveTarget ||
+		s == ModelStatusTimeLimit ||
+		s == ModelStatusIterationLimit ||
+		s == ModelStatusSolutionLimit ||
+		s == ModelStatusInterrupt
+}
+
+// BasisStatus represents the basis status of a variable or constraint.
+type BasisStatus int
+
+const (
+	// BasisStatusLower indicates the variable is at its lower bound.
+	BasisStatusLower BasisStatus = iota
+	// BasisStatusBasic indicates the variable is basic.
+	BasisStatusBasic
+	// BasisStatusUpper indicates the variable is at its upper bound.
+	BasisStatusUpper
+	// BasisStatusZero indicates the variable is free and set to zero.
+	BasisStatusZero
+	// BasisStatusNonbasic indicates the variable is nonbasic.
+	BasisStatusNonbasic
+)
+
+// String returns a human-readable representation of the basis status.
+func (s BasisStatus) String() string {
+	switch s {
+	case BasisStatusLower:
+		return "Lower"
+	case BasisStatusBasic:
+		return "Basic"
+	case BasisStatusUpper:
+		return "Upper"
+	case BasisStatusZero:
+		return "Zero"
+	case BasisStatusNonbasic:
+		return "Nonbasic"
+	default:
+		return "Unknown"
+	}
+}
+
+// MatrixFormat specifies how a constraint matrix is compressed when
+// passed to HiGHS.
+type MatrixFormat int
+
+const (
+	// MatrixFormatRowwise passes the matrix in compressed sparse row format (default).
+	MatrixFormatRowwise MatrixFormat = iota
+	// MatrixFormatColwise passes the matrix in compressed sparse column format.
+	MatrixFormatColwise
+)
+
+// String returns a human-readable representation of the matrix format.
+func (f MatrixFormat) String() string {
+	switch f {
+	case MatrixFormatRowwise:
+		return "Rowwise"
+	case MatrixFormatColwise:
+		return "Colwise"
+	default:
+		return "Unknown"
+	}
+}
+
+// Sense is the direction of a constraint relative to its right-hand side.
+type Sense int
+
+const (
+	// SenseLessEqual is a constraint a·x <= rhs.
+	SenseLessEqual Sense = iota
+	// SenseGreaterEqual is a constraint a·x >= rhs.
+	SenseGreaterEqual
+	// SenseEqual is a constraint a·x = rhs.
+	SenseEqual
+)
+
+// String returns a human-readable representation of the sense.
+func (s Sense) String() string {
+	switch s {
+	case SenseLessEqual:
+		return "<="
+	case SenseGreaterEqual:
+		return ">="
+	case SenseEqual:
+		return "="
+	default:
+		return "Unknown"
+	}
+}
+
+// OptionType is the type of value a HiGHS option takes, which selects the
+// setter and getter to use for it.
+type OptionType int
+
+const (
+	// OptionTypeBool is an option set with SetBoolOption.
+	OptionTypeBool OptionType = iota
+	// OptionTypeInt is an option set with SetIntOption.
+	OptionTypeInt
+	// OptionTypeDouble is an option set with SetFloatOption.
+	OptionTypeDouble
+	// OptionTypeString is an option set with SetStringOption.
+	OptionTypeString
+)
+
+// String returns a human-readable representation of the option type.
+func (t OptionType) String() string {
+	switch t {
+	case OptionTypeBool:
+		return "Bool"
+	case OptionTypeInt:
+		return "Int"
+	case OptionTypeDouble:
+		return "Double"
+	case OptionTypeString:
+		return "String"
+	default:
+		return "Unknown"
+	}
+}
+
+// Nonzero represents a non-zero entry in a sparse matrix.
+// Row and Col are zero-indexed.
+type Nonzero struct {
+	Row int
+	Col int
+	Val float64
+}
+
+// Column is a single variable: its objective coefficient, bounds, and
+// the nonzero coefficients of its constraint matrix column, given as row
+// indices and values.
+type Column struct {
+	Cost   float64
+	Lower  float64
+	Upper  float64
+	Rows   []int
+	Values []float64
+}
+
+// Columns holds the data of a range of columns, as returned by
+// GetColsByRange. The constraint matrix entries of the columns are in
+// compressed sparse column format: column i of the range has entries
+// Index[Start[i]:Start[i+1]] (the last runs to the end of Index).
+type Columns struct {
+	Cost        []float64
+	Lower       []float64
+	Upper       []float64
+	Integrality []VariableType
+	Start       []int
+	Index       []int
+	Value       []float64
+}
+
+// Residuals measures how well the last solution satisfies the bounds,
+// constraints, and optimality conditions of the model, as returned by
+// GetResiduals. HiGHS solves a scaled copy of the model; the C API does
+// not expose that scaled solution, but these measures are taken on the
+// unscaled model, so values near the feasibility tolerances reveal
+// precision lost when unscaling.
+type Residuals struct {
+	// MaxPrimalInfeasibility and SumPrimalInfeasibilities measure the
+	// violation of variable and constraint bounds.
+	MaxPrimalInfeasibility   float64
+	SumPrimalInfeasibilities float64
+
+	// MaxDualInfeasibility and SumDualInfeasibilities measure reduced
+	// costs with the wrong sign.
+	MaxDualInfeasibility   float64
+	SumDualInfeasibilities float64
+
+	// MaxRelativePrimalInfeasibility and MaxRelativeDualInfeasibility are
+	// the maximum infeasibilities relative to the magnitude of the bound
+	// or cost involved.
+	MaxRelativePrimalInfeasibility float64
+	MaxRelativeDualInfeasibility   float64
+
+	// MaxComplementarityViolation is the largest product of a primal
+	// slack and its dual value, nonzero only away from a vertex.
+	MaxComplementarityViolation float64
+
+	// PrimalDualObjectiveError is the relative difference between the
+	// primal and dual objective values.
+	PrimalDualObjectiveError float64
+}
+
+// RangingRecord holds one direction of ranging for each column or row.
+// Value[i] is the cost or bound at which the current basis stops being
+// optimal, and Objective[i] the objective value there. InVar[i] and
+// OutVar[i] are the variables entering and leaving the basis at that
+// point; indices below NumCol are columns, and NumCol+r refers to row r.
+type RangingRecord struct {
+	Value     []float64
+	Objective []float64
+	InVar     []int
+	OutVar    []int
+}
+
+// Ranging is the sensitivity analysis of an optimal LP basis, as returned
+// by Solver.Ranging. Costs and bounds may move within [Down.Value,
+// Up.Value] without changing the optimal basis.
+type Ranging struct {
+	// ColCostUp and ColCostDown range each column's objective coefficient.
+	ColCostUp   RangingRecord
+	ColCostDown RangingRecord
+
+	// ColBoundUp and ColBoundDown range each column's active bound.
+	ColBoundUp   RangingRecord
+	ColBoundDown RangingRecord
+
+	// RowBoundUp and RowBoundDown range each row's active bound (the
+	// right-hand side).
+	RowBoundUp   RangingRecord
+	RowBoundDown RangingRecord
+}
+
+// RHSRange is the range over which a constraint's right-hand side can
+// move while the current basis stays optimal, as returned by
+// Solution.RHSRanges.
+type RHSRange struct {
+	// Bound is the current right-hand side: the active bound of a binding
+	// row, or for a non-binding row the finite bound nearest its
+	// activity (±Inf for a free row).
+	Bound float64
+
+	// Lower and Upper bracket Bound. For a non-binding row the range runs
+	// from its activity to infinity on the side away from the activity.
+	Lower float64
+	Upper float64
+}
+
+// ----------------------------------------------------------------------------
+// Errors
+// ----------------------------------------------------------------------------
+
+// ErrUnsupportedPlatform is returned by NewSolver and Model.Solve when
+// the package is built without the HiGHS library, i.e. on a platform
+// other than linux or darwin on amd64 or arm64, or with cgo disabled.
+var ErrUnsupportedPlatform = errors.New("highs: HiGHS is not available on this platform (requires cgo on linux or darwin, amd64 or arm64)")
+
+// ErrCanceled is returned by Model.SolveContext when its context is
+// done before the solve finishes. The error also wraps the context's
+// error.
+var ErrCanceled = errors.New("highs: solve canceled")
+
+// ErrUnknownName is returned when looking up a variable or constraint by
+// a name the model does not define.
+var ErrUnknownName = errors.New("highs: unknown name")
+
+// Error represents a HiGHS error with context about which operation failed.
+type Error struct {
+	Op     string // Operation that failed (e.g., "Solve", "SetOption")
+	Status Status // HiGHS status code
+	Msg    string // Additional context
+}
+
+func (e *Error) Error() string {
+	if e.Msg != "" {
+		return fmt.Sprintf("highs: %s failed: %s", e.Op, e.Msg)
+	}
+	return fmt.Sprintf("highs: %s failed with status %s", e.Op, e.Status)
+}
+
+// newError creates a new Error if status is not OK.
+// Returns nil if status is OK or Warning.
+func newError(op string, status Status) error {
+	if status == StatusOK || status == StatusWarning {
+		return nil
+	}
+	return &Error{Op: op, Status: status}
+}
+
+// newErrorMsg creates a new Error with an additional message.
+func newErrorMsg(op, msg string) error {
+	return &Error{Op: op, Status: StatusError, Msg: msg}
+}
@@ -0,0 +1,263 @@
+//go:build !cgo || !((linux || darwin) && (amd64 || arm64))
+
+package highs
+
+import (
+	"context"
+	"io"
+	"math"
+)
+
+// HighsInt is the integer type used by HiGHS.
+type HighsInt = int32
+
+// Version returns the empty string, as HiGHS is not available on this
+// platform.
+func Version() string {
+	return ""
+}
+
+// InfinityValue returns math.Inf(1), the value HiGHS uses to represent
+// infinity.
+func InfinityValue() float64 {
+	return math.Inf(1)
+}
+
+// ----------------------------------------------------------------------------
+// Solver (unsupported platform)
+// ----------------------------------------------------------------------------
+
+// Solver provides low-level access to the HiGHS solver. On this platform
+// HiGHS is not available: NewSolver returns ErrUnsupportedPlatform and no
+// Solver can be created.
+type Solver struct{}
+
+// NewSolver returns ErrUnsupportedPlatform.
+func NewSolver() (*Solver, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// NewSolverNoFinalizer returns ErrUnsupportedPlatform.
+func NewSolverNoFinalizer() (*Solver, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// The methods below mirror those of the cgo build so that code using the
+// package compiles everywhere. They return ErrUnsupportedPlatform or zero
+// values; they are unreachable since no Solver can be created.
+
+func (s *Solver) Close()                                 {}
+func (s *Solver) Clear() error                           { return ErrUnsupportedPlatform }
+func (s *Solver) ClearModel() error                      { return ErrUnsupportedPlatform }
+func (s *Solver) ClearSolver() error                     { return ErrUnsupportedPlatform }
+func (s *Solver) ResetOptions() error                    { return ErrUnsupportedPlatform }
+func (s *Solver) Infinity() float64                      { return math.Inf(1) }
+func (s *Solver) NumCol() int                            { return 0 }
+func (s *Solver) NumRow() int                            { return 0 }
+func (s *Solver) NumNonzero() int                        { return 0 }
+func (s *Solver) SetObjectiveTracking(bool)              {}
+func (s *Solver) ObjectiveHistory() []float64            { return nil }
+func (s *Solver) SetSolutionFilter(func([]float64) bool) {}
+
+func (s *Solver) SetBoolOption(string, bool) error       { return ErrUnsupportedPlatform }
+func (s *Solver) SetIntOption(string, int) error         { return ErrUnsupportedPlatform }
+func (s *Solver) SetFloatOption(string, float64) error   { return ErrUnsupportedPlatform }
+func (s *Solver) SetStringOption(string, string) error   { return ErrUnsupportedPlatform }
+func (s *Solver) GetBoolOption(string) (bool, error)     { return false, ErrUnsupportedPlatform }
+func (s *Solver) GetIntOption(string) (int, error)       { return 0, ErrUnsupportedPlatform }
+func (s *Solver) GetFloatOption(string) (float64, error) { return 0, ErrUnsupportedPlatform }
+func (s *Solver) GetStringOption(string) (string, error) { return "", ErrUnsupportedPlatform }
+func (s *Solver) OptionType(string) (OptionType, error)  { return 0, ErrUnsupportedPlatform }
+
+func (s *Solver) IntOptionInfo(name string) (current, def, min, max int, err error) {
+	return 0, 0, 0, 0, ErrUnsupportedPlatform
+}
+
+func (s *Solver) FloatOptionInfo(name string) (current, def, min, max float64, err error) {
+	return 0, 0, 0, 0, ErrUnsupportedPlatform
+}
+
+func (s *Solver) OptionsFingerprint() (string, error) { return "", ErrUnsupportedPlatform }
+
+func (s *Solver) SetMaximize(bool) error                     { return ErrUnsupportedPlatform }
+func (s *Solver) ObjectiveSense() (maximize bool, err error) { return false, ErrUnsupportedPlatform }
+func (s *Solver) ObjectiveValue() float64                    { return 0 }
+func (s *Solver) SetObjectiveOffset(float64) error           { return ErrUnsupportedPlatform }
+func (s *Solver) AddVar(lower, upper float64) (int, error)   { return -1, ErrUnsupportedPlatform }
+func (s *Solver) AddVars(lower, upper []float64) error       { return ErrUnsupportedPlatform }
+func (s *Solver) DeleteColsByRange(from, to int) error       { return ErrUnsupportedPlatform }
+func (s *Solver) DeleteColsBySet(cols []int) error           { return ErrUnsupportedPlatform }
+func (s *Solver) DeleteRowsByRange(from, to int) error       { return ErrUnsupportedPlatform }
+func (s *Solver) DeleteRowsBySet(rows []int) error           { return ErrUnsupportedPlatform }
+func (s *Solver) SetColCost(col int, cost float64) error     { return ErrUnsupportedPlatform }
+func (s *Solver) SetColCosts(costs []float64) error          { return ErrUnsupportedPlatform }
+func (s *Solver) SetColCostsBySet(cols []int, costs []float64) error {
+	return ErrUnsupportedPlatform
+}
+func (s *Solver) SetColBounds(col int, lower, upper float64) error { return ErrUnsupportedPlatform }
+func (s *Solver) SetColBoundsRange(from, to int, lower, upper []float64) error {
+	return ErrUnsupportedPlatform
+}
+func (s *Solver) SetRowBounds(row int, lower, upper float64) error { return ErrUnsupportedPlatform }
+func (s *Solver) SetRowBoundsRange(from, to int, lower, upper []float64) error {
+	return ErrUnsupportedPlatform
+}
+func (s *Solver) SetColIntegrality(col int, varType VariableType) error {
+	return ErrUnsupportedPlatform
+}
+func (s *Solver) SetIntegrality(varTypes []VariableType) error  { return ErrUnsupportedPlatform }
+func (s *Solver) AllContinuous() error                          { return ErrUnsupportedPlatform }
+func (s *Solver) AllInteger() error                             { return ErrUnsupportedPlatform }
+func (s *Solver) Integralities() ([]VariableType, error)        { return nil, ErrUnsupportedPlatform }
+func (s *Solver) SetColNames(names []string, unique bool) error { return ErrUnsupportedPlatform }
+func (s *Solver) SetRowNames(names []string, unique bool) error { return ErrUnsupportedPlatform }
+
+func (s *Solver) AddRow(lower, upper float64, index []int, value []float64) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) AddRows(lower, upper []float64, starts, index []int, value []float64) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) AddCol(cost, lower, upper float64, rows []int, values []float64) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) AddCols(cost, lower, upper []float64, starts, index []int, value []float64) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) PassModel(
+	numCol, numRow int,
+	colCost, colLower, colUpper []float64,
+	rowLower, rowUpper []float64,
+	aStart, aIndex []int,
+	aValue []float64,
+	integrality []VariableType,
+	maximize bool,
+	offset float64,
+) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) passModel(
+	format MatrixFormat,
+	numCol, numRow int,
+	colCost, colLower, colUpper []float64,
+	rowLower, rowUpper []float64,
+	aStart, aIndex []int,
+	aValue []float64,
+	integrality []VariableType,
+	maximize bool,
+	offset float64,
+) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) PassHessian(dim int, start, index []int, value []float64) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) setSolution(colValue []float64) error { return ErrUnsupportedPlatform }
+
+func (s *Solver) SetBasis(colBasis, rowBasis []BasisStatus) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) GetModelStatus() ModelStatus       { return ModelStatusNotSet }
+func (s *Solver) GetScaledModelStatus() ModelStatus { return ModelStatusNotSet }
+
+func (s *Solver) Presolve() (ModelStatus, error) { return ModelStatusNotSet, ErrUnsupportedPlatform }
+func (s *Solver) PresolvedNumCol() int           { return 0 }
+func (s *Solver) PresolvedNumRow() int           { return 0 }
+
+func (s *Solver) Postsolve(colValue []float64) (*Solution, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func (s *Solver) PostsolveDual(colValue, colDual, rowDual []float64) (*Solution, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func (s *Solver) Run() (*Solution, error)     { return nil, ErrUnsupportedPlatform }
+func (s *Solver) RunInto(sol *Solution) error { return ErrUnsupportedPlatform }
+
+func (s *Solver) VerifyIntegrality(tol float64) (bool, []int, error) {
+	return false, nil, ErrUnsupportedPlatform
+}
+
+func (s *Solver) RowActivity(row int) (float64, error)      { return 0, ErrUnsupportedPlatform }
+func (s *Solver) GetIntInfo(name string) (int, error)       { return 0, ErrUnsupportedPlatform }
+func (s *Solver) GetInt64Info(name string) (int64, error)   { return 0, ErrUnsupportedPlatform }
+func (s *Solver) GetFloatInfo(name string) (float64, error) { return 0, ErrUnsupportedPlatform }
+func (s *Solver) GetResiduals() (*Residuals, error)         { return nil, ErrUnsupportedPlatform }
+func (s *Solver) ReadModel(filename string) error           { return ErrUnsupportedPlatform }
+func (s *Solver) WriteModel(filename string) error          { return ErrUnsupportedPlatform }
+func (s *Solver) WritePresolvedModel(filename string) error { return ErrUnsupportedPlatform }
+
+func (s *Solver) GetColsByRange(from, to int) (*Columns, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func (s *Solver) GetCol(col int) (cost, lower, upper float64, rows []int, values []float64, err error) {
+	return 0, 0, 0, nil, nil, ErrUnsupportedPlatform
+}
+
+func (s *Solver) GetRow(row int) (lower, upper float64, cols []int, values []float64, err error) {
+	return 0, 0, nil, nil, ErrUnsupportedPlatform
+}
+
+func (s *Solver) GetModel() (*Model, error) { return nil, ErrUnsupportedPlatform }
+
+func (s *Solver) WriteSolution(filename string, pretty bool) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) Ranging() (*Ranging, error) { return nil, ErrUnsupportedPlatform }
+
+func (s *Solver) PrimalRay() ([]float64, bool, error) { return nil, false, ErrUnsupportedPlatform }
+func (s *Solver) DualRay() ([]float64, bool, error)   { return nil, false, ErrUnsupportedPlatform }
+
+func (s *Solver) getIIS() (rows, cols []int, err error) { return nil, nil, ErrUnsupportedPlatform }
+
+func (s *Solver) feasibilityRelaxation() ([]BoundRelaxation, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func (s *Solver) ColName(col int) (string, error) { return "", ErrUnsupportedPlatform }
+func (s *Solver) RowName(row int) (string, error) { return "", ErrUnsupportedPlatform }
+func (s *Solver) ColNames() ([]string, error)     { return nil, ErrUnsupportedPlatform }
+func (s *Solver) RowNames() ([]string, error)     { return nil, ErrUnsupportedPlatform }
+
+func (s *Solver) GetCoeff(row, col int) (float64, error)        { return 0, ErrUnsupportedPlatform }
+func (s *Solver) ChangeCoeff(row, col int, value float64) error { return ErrUnsupportedPlatform }
+func (s *Solver) ChangeCoeffs(entries []Nonzero) error          { return ErrUnsupportedPlatform }
+
+func (s *Solver) SetLogWriter(w io.Writer) error { return ErrUnsupportedPlatform }
+
+func (s *Solver) SetWarningCapture(enabled bool) error { return ErrUnsupportedPlatform }
+
+func (s *Solver) SetInterruptCallback(fn func(data CallbackData) bool) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) WriteOptions(filename string) error         { return ErrUnsupportedPlatform }
+func (s *Solver) WriteModifiedOptions(filename string) error { return ErrUnsupportedPlatform }
+func (s *Solver) ReadOptions(filename string) error          { return ErrUnsupportedPlatform }
+
+func (s *Solver) setContext(ctx context.Context) error { return ErrUnsupportedPlatform }
+
+func (s *Solver) setMIPProgress(record func(MIPProgress)) error { return ErrUnsupportedPlatform }
+
+func (s *Solver) setStopAtFirstFeasible() error { return ErrUnsupportedPlatform }
+
+func (s *Solver) stopCallbacks() error { return nil }
+
+func (s *Solver) setSolutionCollector(max int, pool *[]PoolEntry) error {
+	return ErrUnsupportedPlatform
+}
+
+func (s *Solver) setProgressReporter(r *progressReporter) error { return ErrUnsupportedPlatform }
+
+func (r *progressReporter) finish(s *Solver, sol *Solution, maximize bool) {}
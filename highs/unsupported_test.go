@@ -0,0 +1,26 @@
+//go:build !cgo || !((linux || darwin) && (amd64 || arm64))
+
+package highs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnsupportedPlatform(t *testing.T) {
+	if _, err := NewSolver(); !errors.Is(err, ErrUnsupportedPlatform) {
+		t.Errorf("NewSolver error = %v, want ErrUnsupportedPlatform", err)
+	}
+	if _, err := NewSolverNoFinalizer(); !errors.Is(err, ErrUnsupportedPlatform) {
+		t.Errorf("NewSolverNoFinalizer error = %v, want ErrUnsupportedPlatform", err)
+	}
+
+	model := Model{
+		ColCosts: []float64{1, 1},
+		ColLower: []float64{0, 0},
+		ColUpper: []float64{10, 10},
+	}
+	if _, err := model.Solve(); !errors.Is(err, ErrUnsupportedPlatform) {
+		t.Errorf("Solve error = %v, want ErrUnsupportedPlatform", err)
+	}
+}
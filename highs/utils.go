@@ -1,6 +1,7 @@
 package highs
 
 import (
+	"fmt"
 	"math"
 	"sort"
 )
@@ -15,17 +16,40 @@ func NegInf() float64 {
 	return math.Inf(-1)
 }
 
-// nonzerosToCSR converts a slice of Nonzero elements to compressed sparse row format.
+// infiniteBound is the magnitude at or above which HiGHS treats a bound
+// as infinite (the default of its infinite_bound option).
+const infiniteBound = 1e20
+
+// isFiniteBound reports whether HiGHS treats the bound as finite.
+func isFiniteBound(v float64) bool {
+	return math.Abs(v) < infiniteBound
+}
+
+// boundsError describes why a lower/upper bound pair is invalid, or
+// returns an empty string if the bounds are valid.
+func boundsError(lower, upper float64) string {
+	if math.IsNaN(lower) || math.IsNaN(upper) {
+		return "bounds must not be NaN"
+	}
+	if lower > upper {
+		return fmt.Sprintf("lower bound %g exceeds upper bound %g", lower, upper)
+	}
+	return ""
+}
+
+// nonzerosToCSR converts a slice of Nonzero elements to compressed sparse
+// row format with one start per row, so rows without entries are represented
+// correctly. Duplicate entries are merged, keeping the last value.
 // If triangular is true, it validates that the matrix is upper triangular.
-func nonzerosToCSR(nz []Nonzero, triangular bool) (start, index []int, value []float64, err error) {
+func nonzerosToCSR(nz []Nonzero, numRow int, triangular bool) (start, index []int, value []float64, err error) {
 	if len(nz) == 0 {
 		return nil, nil, nil, nil
 	}
 
-	// Sort by row, then by column
+	// Sort by row, then by column; stable so the last duplicate wins
 	sorted := make([]Nonzero, len(nz))
 	copy(sorted, nz)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		if sorted[i].Row != sorted[j].Row {
 			return sorted[i].Row < sorted[j].Row
 		}
@@ -38,6 +62,9 @@ func nonzerosToCSR(nz []Nonzero, triangular bool) (start, index []int, value []f
 		if n.Row < 0 || n.Col < 0 {
 			return nil, nil, nil, newErrorMsg("nonzerosToCSR", "negative row or column index")
 		}
+		if n.Row >= numRow {
+			return nil, nil, nil, newErrorMsg("nonzerosToCSR", "row index out of range")
+		}
 		if triangular && n.Row > n.Col {
 			return nil, nil, nil, newErrorMsg("nonzerosToCSR", "Hessian must be upper triangular")
 		}
@@ -50,19 +77,87 @@ func nonzerosToCSR(nz []Nonzero, triangular bool) (start, index []int, value []f
 	}
 
 	// Build CSR format
-	start = make([]int, 0)
+	start = make([]int, numRow)
 	index = make([]int, len(filtered))
 	value = make([]float64, len(filtered))
 
-	prevRow := -1
+	row := 0
 	for i, n := range filtered {
-		if n.Row > prevRow {
-			start = append(start, i)
-			prevRow = n.Row
+		for row <= n.Row {
+			start[row] = i
+			row++
 		}
 		index[i] = n.Col
 		value[i] = n.Val
 	}
+	for ; row < numRow; row++ {
+		start[row] = len(filtered)
+	}
+
+	return start, index, value, nil
+}
+
+// nonzerosToCSC converts a slice of Nonzero elements to compressed sparse
+// column format with one start per column, so columns without entries are
+// represented correctly. Duplicate entries are merged, keeping the last value.
+func nonzerosToCSC(nz []Nonzero, numCol int) (start, index []int, value []float64, err error) {
+	if len(nz) == 0 {
+		return nil, nil, nil, nil
+	}
+
+	// Validate and count entries per column
+	count := make([]int, numCol+1)
+	for _, n := range nz {
+		if n.Row < 0 || n.Col < 0 {
+			return nil, nil, nil, newErrorMsg("nonzerosToCSC", "negative row or column index")
+		}
+		if n.Col >= numCol {
+			return nil, nil, nil, newErrorMsg("nonzerosToCSC", "column index out of range")
+		}
+		count[n.Col+1]++
+	}
+	for col := 0; col < numCol; col++ {
+		count[col+1] += count[col]
+	}
+
+	// Bucket by column keeping input order, then sort each column by row
+	sorted := make([]Nonzero, len(nz))
+	next := append([]int(nil), count[:numCol]...)
+	for _, n := range nz {
+		sorted[next[n.Col]] = n
+		next[n.Col]++
+	}
+	for col := 0; col < numCol; col++ {
+		entries := sorted[count[col]:count[col+1]]
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Row < entries[j].Row
+		})
+	}
+
+	// Deduplicate
+	filtered := make([]Nonzero, 0, len(sorted))
+	for _, n := range sorted {
+		if len(filtered) > 0 && filtered[len(filtered)-1].Row == n.Row && filtered[len(filtered)-1].Col == n.Col {
+			filtered[len(filtered)-1].Val = n.Val
+		} else {
+			filtered = append(filtered, n)
+		}
+	}
+
+	// Build CSC format
+	start = make([]int, numCol)
+	index = make([]int, len(filtered))
+	value = make([]float64, len(filtered))
+
+	k := 0
+	for col := 0; col < numCol; col++ {
+		start[col] = k
+		for k < len(filtered) && filtered[k].Col == col {
+			index[k] = filtered[k].Row
+			value[k] = filtered[k].Val
+			k++
+		}
+	}
 
 	return start, index, value, nil
 }
@@ -97,3 +192,80 @@ func maxRowCol(nz []Nonzero) (maxRow, maxCol int) {
 	}
 	return maxRow, maxCol
 }
+
+// maxConvexityCheckDim caps the number of distinct Hessian columns that
+// hessianConvexity factorizes densely; larger Hessians are left unchecked.
+const maxConvexityCheckDim = 1000
+
+// convexity is the result of checking a QP Hessian for convexity.
+type convexity int
+
+const (
+	// convexityUnchecked means the Hessian was not checked, because it
+	// was not passed through PassHessian or is too large.
+	convexityUnchecked convexity = iota
+	convexityConvex
+	convexityNonConvex
+)
+
+// hessianConvexity reports whether the symmetric matrix with the given
+// triangular entries is positive semidefinite, or negative semidefinite
+// when maximize is set, making the QP objective convex. It runs a dense
+// Cholesky factorization restricted to the columns that appear in the
+// entries, treating pivots within a relative tolerance of zero as zero.
+func hessianConvexity(hessian []Nonzero, maximize bool) convexity {
+	pos := make(map[int]int)
+	for _, nz := range hessian {
+		for _, k := range [2]int{nz.Row, nz.Col} {
+			if _, ok := pos[k]; !ok {
+				pos[k] = len(pos)
+			}
+		}
+	}
+	n := len(pos)
+	if n > maxConvexityCheckDim {
+		return convexityUnchecked
+	}
+
+	sign := 1.0
+	if maximize {
+		sign = -1
+	}
+	a := make([]float64, n*n)
+	var scale float64
+	for _, nz := range hessian {
+		i, j := pos[nz.Row], pos[nz.Col]
+		a[i*n+j] += sign * nz.Val
+		if i != j {
+			a[j*n+i] += sign * nz.Val
+		}
+		scale = max(scale, math.Abs(nz.Val))
+	}
+	tol := 1e-9 * scale
+
+	for k := 0; k < n; k++ {
+		pivot := a[k*n+k]
+		if pivot < -tol {
+			return convexityNonConvex
+		}
+		if pivot <= tol {
+			// A zero pivot requires the rest of its column to vanish
+			for i := k + 1; i < n; i++ {
+				if math.Abs(a[i*n+k]) > tol {
+					return convexityNonConvex
+				}
+			}
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			f := a[i*n+k] / pivot
+			if f == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= f * a[k*n+j]
+			}
+		}
+	}
+	return convexityConvex
+}